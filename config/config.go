@@ -8,8 +8,10 @@ import (
 )
 
 type Config struct {
-	Server ServerConfig
-	DB     DBConfig
+	Server    ServerConfig
+	DB        DBConfig
+	Scheduler SchedulerConfig
+	Notifier  NotifierConfig
 }
 
 type ServerConfig struct {
@@ -26,6 +28,29 @@ type DBConfig struct {
 	MaxConnLifetime time.Duration `env:"DB_MAX_CONN_LIFETIME"`
 }
 
+type SchedulerConfig struct {
+	Enabled         bool          `env:"SCHEDULER_ENABLED" env-default:"true"`
+	ShutdownTimeout time.Duration `env:"SCHEDULER_SHUTDOWN_TIMEOUT" env-default:"30s"`
+
+	// эскалация просроченных по SLA ревью, пустое расписание отключает задачу
+	EscalationSchedule string        `env:"ESCALATION_SCHEDULE" env-default:"*/10 * * * *"`
+	EscalationJitter   time.Duration `env:"ESCALATION_JITTER" env-default:"30s"`
+	EscalationTimeout  time.Duration `env:"ESCALATION_TIMEOUT" env-default:"1m"`
+
+	// напоминания ревьюверам: через REMINDER_AFTER после назначения, не чаще раза в REMINDER_INTERVAL
+	ReminderSchedule string        `env:"REMINDER_SCHEDULE" env-default:"0 * * * *"`
+	ReminderAfter    time.Duration `env:"REMINDER_AFTER" env-default:"24h"`
+	ReminderInterval time.Duration `env:"REMINDER_INTERVAL" env-default:"24h"`
+	ReminderJitter   time.Duration `env:"REMINDER_JITTER" env-default:"1m"`
+	ReminderTimeout  time.Duration `env:"REMINDER_TIMEOUT" env-default:"5m"`
+}
+
+type NotifierConfig struct {
+	Type           string        `env:"NOTIFIER" env-default:"log"` // log или webhook
+	WebhookURL     string        `env:"NOTIFIER_WEBHOOK_URL"`
+	WebhookTimeout time.Duration `env:"NOTIFIER_WEBHOOK_TIMEOUT" env-default:"5s"`
+}
+
 func MustLoad() *Config {
 	var cfg Config
 
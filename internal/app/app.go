@@ -15,6 +15,7 @@ import (
 	"pr-reviewer-service/internal/db"
 	"pr-reviewer-service/internal/logger"
 	"pr-reviewer-service/internal/repo"
+	"pr-reviewer-service/internal/scheduler"
 	"pr-reviewer-service/internal/service"
 	httptransport "pr-reviewer-service/internal/transport/http"
 )
@@ -53,21 +54,51 @@ func Run() {
 	userRepo := repo.NewUserRepo(pool)
 	prRepo := repo.NewPullRequestRepo(pool)
 	statsRepo := repo.NewStatsRepo(pool)
+	ownershipRepo := repo.NewOwnershipRepo(pool)
+	unavailabilityRepo := repo.NewUnavailabilityRepo(pool)
+	exclusionRepo := repo.NewReviewExclusionRepo(pool)
+	repositoryRepo := repo.NewRepositoryRepo(pool)
+	reminderRepo := repo.NewReminderRepo(pool)
+	txManager := repo.NewTxManager(pool)
 	log.Info("Successfully initialized repositories")
 
 	//services
 	log.Info("Initializing services...")
-	teamService := service.NewTeamService(teamRepo, userRepo)
-	userService := service.NewUserService(userRepo, prRepo)
-	prService := service.NewPRService(prRepo, userRepo, teamRepo)
-	statsService := service.NewStatsService(statsRepo)
+	prService := service.NewPRService(prRepo, userRepo, teamRepo, ownershipRepo, exclusionRepo, repositoryRepo, txManager, service.SystemClock())
+	teamService := service.NewTeamService(teamRepo, userRepo, txManager, prService)
+	userService := service.NewUserService(userRepo, prRepo, unavailabilityRepo, repositoryRepo, txManager, prService)
+	statsService := service.NewStatsService(statsRepo, repositoryRepo)
+	ownershipService := service.NewOwnershipService(ownershipRepo, teamRepo, userRepo)
+	exclusionService := service.NewExclusionService(exclusionRepo, userRepo)
+	repositoryService := service.NewRepositoryService(repositoryRepo, teamRepo)
+
+	notifier, err := newNotifier(cfg.Notifier, log)
+	if err != nil {
+		log.Error("Failed to create notifier", slog.Any("error", err))
+		os.Exit(1)
+	}
+	jobLocker := repo.NewJobLocker(pool)
+	reminderService := service.NewReminderService(reminderRepo, prRepo, userRepo, notifier, jobLocker, service.SystemClock(),
+		cfg.Scheduler.ReminderAfter, cfg.Scheduler.ReminderInterval)
+
+	//scheduler
+	jobs := scheduler.New(jobLocker, scheduler.SystemClock(), log)
+	if err := registerJobs(jobs, cfg.Scheduler, prService, reminderService, log); err != nil {
+		log.Error("Failed to register jobs", slog.Any("error", err))
+		os.Exit(1)
+	}
 
 	r := httptransport.NewRouter(httptransport.Dependencies{
-		TeamService:  teamService,
-		UserService:  userService,
-		PRService:    prService,
-		StatsService: statsService,
-		Logger:       log,
+		TeamService:       teamService,
+		UserService:       userService,
+		PRService:         prService,
+		StatsService:      statsService,
+		OwnershipService:  ownershipService,
+		ExclusionService:  exclusionService,
+		RepositoryService: repositoryService,
+		ReminderService:   reminderService,
+		Scheduler:         jobs,
+		Logger:            log,
 	})
 
 	srv := &http.Server{
@@ -86,6 +117,11 @@ func Run() {
 		}
 	}()
 
+	if cfg.Scheduler.Enabled {
+		jobs.Start()
+		log.Info("scheduler started")
+	}
+
 	<-quit
 	log.Info("shutting down server...")
 
@@ -98,4 +134,14 @@ func Run() {
 		log.Info("server exited gracefully")
 	}
 
+	// задачи дорабатывают до SCHEDULER_SHUTDOWN_TIMEOUT, затем им отменяется контекст
+	ctxJobs, cancelJobs := context.WithTimeout(context.Background(), cfg.Scheduler.ShutdownTimeout)
+	defer cancelJobs()
+
+	if err := jobs.Stop(ctxJobs); err != nil {
+		log.Error("scheduler jobs were cancelled", slog.Any("error", err))
+	} else {
+		log.Info("scheduler stopped")
+	}
+
 }
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"pr-reviewer-service/config"
+	"pr-reviewer-service/internal/notify"
+	"pr-reviewer-service/internal/scheduler"
+	"pr-reviewer-service/internal/service"
+)
+
+// периодические задачи сервиса, новые задачи добавляются здесь
+func registerJobs(s *scheduler.Scheduler, cfg config.SchedulerConfig, prService *service.PRService, reminderService *service.ReminderService, log *slog.Logger) error {
+	if cfg.EscalationSchedule != "" {
+		err := s.Register(scheduler.Job{
+			Name:     "escalate-overdue-reviews",
+			Schedule: cfg.EscalationSchedule,
+			Jitter:   cfg.EscalationJitter,
+			Timeout:  cfg.EscalationTimeout,
+			Run: func(ctx context.Context) error {
+				escalations, err := prService.EscalateOverdue(ctx)
+				if err != nil {
+					return err
+				}
+				for _, e := range escalations {
+					if e.Error != "" {
+						log.Warn("review escalation not applied",
+							slog.String("pull_request_id", e.PullRequestID),
+							slog.String("reviewer_id", e.ReviewerID),
+							slog.String("action", string(e.Action)),
+							slog.String("error", string(e.Error)),
+						)
+						continue
+					}
+					log.Info("review escalated",
+						slog.String("pull_request_id", e.PullRequestID),
+						slog.String("reviewer_id", e.ReviewerID),
+						slog.String("action", string(e.Action)),
+						slog.String("escalated_to", e.EscalatedTo),
+					)
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.ReminderSchedule != "" {
+		err := s.Register(scheduler.Job{
+			Name:     service.ReminderJob,
+			Schedule: cfg.ReminderSchedule,
+			Jitter:   cfg.ReminderJitter,
+			Timeout:  cfg.ReminderTimeout,
+			Run: func(ctx context.Context) error {
+				report, err := reminderService.SendReminders(ctx)
+				if err != nil {
+					return err
+				}
+				if len(report.Failed) > 0 {
+					for _, f := range report.Failed {
+						log.Warn("reminder not delivered",
+							slog.String("pull_request_id", f.PullRequestID),
+							slog.String("reviewer_id", f.ReviewerID),
+							slog.String("error", f.Error),
+						)
+					}
+					return fmt.Errorf("%d of %d reminders not delivered", len(report.Failed), len(report.Failed)+len(report.Sent))
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// канал уведомлений из NOTIFIER
+func newNotifier(cfg config.NotifierConfig, log *slog.Logger) (notify.Notifier, error) {
+	switch cfg.Type {
+	case "", "log":
+		return notify.NewLogNotifier(log), nil
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			return nil, errors.New("NOTIFIER_WEBHOOK_URL is required for webhook notifier")
+		}
+		return notify.NewWebhookNotifier(cfg.WebhookURL, cfg.WebhookTimeout), nil
+	}
+	return nil, fmt.Errorf("unknown notifier %q", cfg.Type)
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+type AssignmentAction string
+
+const (
+	AssignmentActionCreate   AssignmentAction = "CREATE"
+	AssignmentActionReassign AssignmentAction = "REASSIGN"
+	AssignmentActionManual   AssignmentAction = "MANUAL"   // ревьюверы добавлены вручную
+	AssignmentActionReady    AssignmentAction = "READY"    // черновик переведен в OPEN
+	AssignmentActionEscalate AssignmentAction = "ESCALATE" // по SLA добавлен пользователь для эскалации
+)
+
+// почему кандидат выбран или пропущен
+type CandidateReason string
+
+const (
+	// выбран
+	CandidateReasonCodeOwner  CandidateReason = "CODE_OWNER"  // явно указан владельцем в CODEOWNERS
+	CandidateReasonSelected   CandidateReason = "SELECTED"    // выбран стратегией команды
+	CandidateReasonLabelMatch CandidateReason = "LABEL_MATCH" // добавлен, чтобы хотя бы один ревьювер подходил по меткам
+	CandidateReasonRequested  CandidateReason = "REQUESTED"   // указан явно в запросе
+
+	// пропущен
+	CandidateReasonAuthor          CandidateReason = "AUTHOR"
+	CandidateReasonReplaced        CandidateReason = "REPLACED" // заменяемый ревьювер
+	CandidateReasonAlreadyAssigned CandidateReason = "ALREADY_ASSIGNED"
+	CandidateReasonInactive        CandidateReason = "INACTIVE"
+	CandidateReasonOutOfOffice     CandidateReason = "OUT_OF_OFFICE"
+	CandidateReasonUnavailable     CandidateReason = "UNAVAILABLE" // владелец из CODEOWNERS неактивен, отсутствует или не найден
+	CandidateReasonAtCapacity      CandidateReason = "AT_CAPACITY"
+	CandidateReasonConflict        CandidateReason = "CONFLICT_OF_INTEREST" // пара с автором в списке исключений
+	CandidateReasonNotSelected     CandidateReason = "NOT_SELECTED"         // стратегия выбрала других или ревьюверов уже хватило
+)
+
+type CandidateExplanation struct {
+	UserID   string          `json:"user_id"`
+	TeamName string          `json:"team_name"` // команда-пул, в которой рассматривался кандидат
+	Picked   bool            `json:"picked"`
+	Reason   CandidateReason `json:"reason"`
+	Detail   string          `json:"detail,omitempty"`
+}
+
+// кого рассматривали при одном назначении ревьюверов и почему выбрали или пропустили
+type AssignmentExplanation struct {
+	PullRequestID      string                 `json:"pull_request_id"`
+	Action             AssignmentAction       `json:"action"`
+	ReplacedReviewerID string                 `json:"replaced_reviewer_id,omitempty"`
+	CreatedAt          time.Time              `json:"created_at"`
+	Candidates         []CandidateExplanation `json:"candidates"`
+}
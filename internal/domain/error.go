@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 type ErrorCode string
 
@@ -11,6 +14,36 @@ const (
 	ErrorNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrorNoCandidate ErrorCode = "NO_CANDIDATE"
 	ErrorNotFound    ErrorCode = "NOT_FOUND"
+
+	// явный выбор нового ревьювера в /pullRequest/reassign
+	ErrorReviewerNotFound ErrorCode = "REVIEWER_NOT_FOUND"
+	ErrorReviewerInactive ErrorCode = "REVIEWER_INACTIVE"
+	ErrorReviewerIsAuthor ErrorCode = "REVIEWER_IS_AUTHOR"
+	ErrorAlreadyAssigned  ErrorCode = "ALREADY_ASSIGNED"
+	ErrorTeamMismatch     ErrorCode = "TEAM_MISMATCH"
+
+	// пользователь в периоде отсутствия
+	ErrorReviewerUnavailable ErrorCode = "REVIEWER_UNAVAILABLE"
+
+	ErrorTooManyReviewers ErrorCode = "TOO_MANY_REVIEWERS"
+
+	// правила конфликта интересов
+	ErrorConflictOfInterest ErrorCode = "CONFLICT_OF_INTEREST"
+	ErrorExclusionExists    ErrorCode = "EXCLUSION_EXISTS"
+
+	// жизненный цикл PR: DRAFT, OPEN, MERGED, CLOSED
+	ErrorPRNotOpen         ErrorCode = "PR_NOT_OPEN"
+	ErrorInvalidTransition ErrorCode = "INVALID_TRANSITION"
+
+	// репозитории PR
+	ErrorRepositoryNotFound ErrorCode = "REPOSITORY_NOT_FOUND"
+	ErrorRepositoryExists   ErrorCode = "REPOSITORY_EXISTS"
+
+	// не выполнены условия merge политики команды автора, список в details
+	ErrorMergeBlocked ErrorCode = "MERGE_BLOCKED"
+
+	// та же задача сейчас выполняется планировщиком или другим запросом
+	ErrorJobRunning ErrorCode = "JOB_RUNNING"
 )
 
 // чтобы удобно было сравнивать через errors.Is
@@ -21,11 +54,48 @@ var (
 	ErrPRMerged    = errors.New("pull request already merged")
 	ErrNoCandidate = errors.New("no candidate")
 	ErrNotAssigned = errors.New("not assigned to this PR")
+
+	ErrReviewerNotFound = errors.New("reviewer not found")
+	ErrReviewerInactive = errors.New("reviewer is inactive")
+	ErrReviewerIsAuthor = errors.New("reviewer is the PR author")
+	ErrAlreadyAssigned  = errors.New("reviewer already assigned to this PR")
+	ErrTeamMismatch     = errors.New("reviewer is not in the team of the replaced reviewer")
+	ErrTooManyReviewers = errors.New("too many reviewers")
+
+	ErrConflictOfInterest = errors.New("reviewer and author must not review each other")
+	ErrExclusionExists    = errors.New("exclusion already exists")
+
+	ErrPRNotOpen         = errors.New("pull request is not open")
+	ErrInvalidTransition = errors.New("invalid pull request status transition")
+
+	ErrRepositoryNotFound = errors.New("repository not found")
+	ErrRepositoryExists   = errors.New("repository already exists")
+
+	ErrMergeBlocked = errors.New("merge blocked")
+
+	ErrJobRunning = errors.New("job is already running")
+
+	ErrInvalidPolicy     = errors.New("invalid review policy")
+	ErrInvalidCodeowners = errors.New("invalid CODEOWNERS")
 )
 
 type Error struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
+	Details []string  `json:"details,omitempty"`
+}
+
+// невыполненные условия merge, errors.Is(err, ErrMergeBlocked) == true
+type MergeBlockedError struct {
+	Conditions []string
+}
+
+func (e *MergeBlockedError) Error() string {
+	return ErrMergeBlocked.Error() + ": " + strings.Join(e.Conditions, "; ")
+}
+
+func (e *MergeBlockedError) Is(target error) bool {
+	return target == ErrMergeBlocked
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,8 @@
+package domain
+
+// строка CODEOWNERS: glob-шаблон пути и владельцы
+type OwnershipRule struct {
+	Pattern string   `json:"pattern"`
+	Teams   []string `json:"teams"`
+	Users   []string `json:"users"`
+}
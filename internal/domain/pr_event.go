@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+type PREventType string
+
+const (
+	PREventCreated            PREventType = "CREATED"
+	PREventReady              PREventType = "READY" // черновик переведен в OPEN
+	PREventReviewerAssigned   PREventType = "REVIEWER_ASSIGNED"
+	PREventReviewerReassigned PREventType = "REVIEWER_REASSIGNED"
+	PREventReviewerRemoved    PREventType = "REVIEWER_REMOVED"
+	PREventReviewed           PREventType = "REVIEWED"
+	PREventMerged             PREventType = "MERGED"
+	PREventClosed             PREventType = "CLOSED"
+	PREventReopened           PREventType = "REOPENED"
+)
+
+// что вызвало изменение ревьюверов
+type PREventReason string
+
+const (
+	PREventReasonCreate       PREventReason = "CREATE"
+	PREventReasonReady        PREventReason = "READY"
+	PREventReasonReassign     PREventReason = "REASSIGN"     // /pullRequest/reassign
+	PREventReasonManual       PREventReason = "MANUAL"       // ручное изменение списка ревьюверов
+	PREventReasonDeactivation PREventReason = "DEACTIVATION" // ревьювер деактивирован
+	PREventReasonEscalation   PREventReason = "ESCALATION"   // эскалация по SLA
+	PREventReasonForce        PREventReason = "FORCE"        // merge в обход merge политики
+	PREventReasonMigration    PREventReason = "MIGRATION"    // восстановлено из данных до появления истории
+)
+
+// запись истории PR, только добавляется
+type PREvent struct {
+	ID            int64             `json:"id"`
+	PullRequestID string            `json:"pull_request_id"`
+	Type          PREventType       `json:"type"`
+	ReviewerID    string            `json:"reviewer_id,omitempty"`     // ASSIGNED, REMOVED, REVIEWED
+	OldReviewerID string            `json:"old_reviewer_id,omitempty"` // REASSIGNED
+	NewReviewerID string            `json:"new_reviewer_id,omitempty"` // REASSIGNED
+	Status        PullRequestStatus `json:"status,omitempty"`          // статус PR после события
+	State         ReviewState       `json:"state,omitempty"`           // решение для REVIEWED
+	Reason        PREventReason     `json:"reason,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// события назначения и снятия ревьюверов при переходе от списка before к after
+func ReviewerChangeEvents(prID string, before, after []PullRequestReviewer, reason PREventReason, at time.Time) []PREvent {
+	was := make(map[string]struct{}, len(before))
+	for _, rv := range before {
+		was[rv.UserID] = struct{}{}
+	}
+	now := make(map[string]struct{}, len(after))
+	for _, rv := range after {
+		now[rv.UserID] = struct{}{}
+	}
+
+	events := make([]PREvent, 0)
+	for _, rv := range before {
+		if _, ok := now[rv.UserID]; !ok {
+			events = append(events, PREvent{
+				PullRequestID: prID,
+				Type:          PREventReviewerRemoved,
+				ReviewerID:    rv.UserID,
+				Reason:        reason,
+				CreatedAt:     at,
+			})
+		}
+	}
+	for _, rv := range after {
+		if _, ok := was[rv.UserID]; !ok {
+			events = append(events, PREvent{
+				PullRequestID: prID,
+				Type:          PREventReviewerAssigned,
+				ReviewerID:    rv.UserID,
+				Reason:        reason,
+				CreatedAt:     at,
+			})
+		}
+	}
+	return events
+}
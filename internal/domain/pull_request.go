@@ -1,22 +1,209 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+)
 
 type PullRequestStatus string
 
 const (
+	PullRequestStatusDraft  PullRequestStatus = "DRAFT" // ревьюверы назначаются после markReady
 	PullRequestStatusOpen   PullRequestStatus = "OPEN"
 	PullRequestStatusMerged PullRequestStatus = "MERGED"
+	PullRequestStatusClosed PullRequestStatus = "CLOSED" // отклонен без merge, можно переоткрыть
+)
+
+// допустимые переходы статусов, MERGED — конечный
+var pullRequestTransitions = map[PullRequestStatus][]PullRequestStatus{
+	PullRequestStatusDraft:  {PullRequestStatusOpen, PullRequestStatusClosed},
+	PullRequestStatusOpen:   {PullRequestStatusMerged, PullRequestStatusClosed},
+	PullRequestStatusClosed: {PullRequestStatusOpen, PullRequestStatusDraft},
+}
+
+func (s PullRequestStatus) CanTransitionTo(next PullRequestStatus) bool {
+	for _, st := range pullRequestTransitions[s] {
+		if st == next {
+			return true
+		}
+	}
+	return false
+}
+
+// решение ревьювера по PR
+type ReviewState string
+
+const (
+	ReviewStatePending          ReviewState = "PENDING" // назначен, решения еще нет
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewStateCommented        ReviewState = "COMMENTED"
 )
 
+// решения, которые ревьювер может отправить через /pullRequest/review
+func (s ReviewState) IsDecision() bool {
+	switch s {
+	case ReviewStateApproved, ReviewStateChangesRequested, ReviewStateCommented:
+		return true
+	}
+	return false
+}
+
+type PullRequestReviewer struct {
+	UserID     string      `json:"user_id"`
+	State      ReviewState `json:"state"`
+	AssignedAt *time.Time  `json:"assigned_at,omitempty"`
+	ReviewedAt *time.Time  `json:"reviewed_at,omitempty"` // время последнего решения
+}
+
 type PullRequest struct {
-	PullRequestID     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	AuthorID          string            `json:"author_id"`
-	Status            PullRequestStatus `json:"status"`
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	CreatedAt         *time.Time        `json:"createdAt,omitempty"`
-	MergedAt          *time.Time        `json:"mergedAt,omitempty"`
+	PullRequestID     string                `json:"pull_request_id"`
+	Number            string                `json:"number"` // номер внутри репозитория, уникален вместе с repository
+	PullRequestName   string                `json:"pull_request_name"`
+	AuthorID          string                `json:"author_id"`
+	Status            PullRequestStatus     `json:"status"`
+	Reviewers         []PullRequestReviewer `json:"reviewers"`
+	Labels            []string              `json:"labels,omitempty"`
+	CreatedAt         *time.Time            `json:"createdAt,omitempty"`
+	MergedAt          *time.Time            `json:"mergedAt,omitempty"`
+	ReadyAt           *time.Time            `json:"readyAt,omitempty"`            // когда PR стал готов к ревью, у черновиков пусто
+	ClosedAt          *time.Time            `json:"closedAt,omitempty"`           // сбрасывается при reopen
+	Understaffed      bool                  `json:"understaffed,omitempty"`       // ревьюверов меньше, чем min_reviewers команды автора
+	FallbackReviewers []string              `json:"fallback_reviewers,omitempty"` // ревьюверы не из команды автора
+	ForceMerged       bool                  `json:"force_merged,omitempty"`       // merge с force в обход merge политики
+	MergeBypassed     []string              `json:"merge_bypassed,omitempty"`     // какие условия были не выполнены при force
+	PullRequestMetadata
+}
+
+// данные PR из системы контроля версий, передаются при создании как есть
+type PullRequestMetadata struct {
+	Repository   string `json:"repository,omitempty"`
+	SourceBranch string `json:"source_branch,omitempty"`
+	TargetBranch string `json:"target_branch,omitempty"`
+	URL          string `json:"url,omitempty"`
+	Description  string `json:"description,omitempty"`
+	LinesAdded   int    `json:"lines_added,omitempty"`
+	LinesRemoved int    `json:"lines_removed,omitempty"`
+	FilesChanged int    `json:"files_changed,omitempty"`
+}
+
+func (m PullRequestMetadata) Validate() error {
+	if m.LinesAdded < 0 || m.LinesRemoved < 0 || m.FilesChanged < 0 {
+		return errors.New("lines_added, lines_removed and files_changed must not be negative")
+	}
+	if m.URL != "" {
+		u, err := url.Parse(m.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("url must be an absolute http(s) URL")
+		}
+	}
+	return nil
+}
+
+// в ответах остается assigned_reviewers — список user_id, как было до состояний ревью
+func (pr PullRequest) MarshalJSON() ([]byte, error) {
+	type pullRequest PullRequest
+	return json.Marshal(struct {
+		pullRequest
+		AssignedReviewers []string `json:"assigned_reviewers"`
+	}{pullRequest(pr), pr.ReviewerIDs()})
+}
+
+func (pr PullRequest) ReviewerIDs() []string {
+	ids := make([]string, 0, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		ids = append(ids, r.UserID)
+	}
+	return ids
+}
+
+// TransitionTo меняет статус и связанные с ним отметки времени.
+// Из MERGED переходов нет (ErrPRMerged), остальные недопустимые — ErrInvalidTransition
+func (pr *PullRequest) TransitionTo(next PullRequestStatus, at time.Time) error {
+	if !pr.Status.CanTransitionTo(next) {
+		if pr.Status == PullRequestStatusMerged {
+			return ErrPRMerged
+		}
+		return ErrInvalidTransition
+	}
+
+	switch next {
+	case PullRequestStatusOpen:
+		if pr.ReadyAt == nil {
+			pr.ReadyAt = &at
+		}
+		pr.ClosedAt = nil
+	case PullRequestStatusDraft:
+		pr.ClosedAt = nil
+	case PullRequestStatusMerged:
+		pr.MergedAt = &at
+	case PullRequestStatusClosed:
+		pr.ClosedAt = &at
+	}
+	pr.Status = next
+	return nil
+}
+
+// ErrPRMerged для MERGED, ErrPRNotOpen для DRAFT и CLOSED
+func (pr PullRequest) CheckOpen() error {
+	switch pr.Status {
+	case PullRequestStatusOpen:
+		return nil
+	case PullRequestStatusMerged:
+		return ErrPRMerged
+	}
+	return ErrPRNotOpen
+}
+
+// сколько текущих ревьюверов одобрили PR
+func (pr PullRequest) Approvals() int {
+	n := 0
+	for _, r := range pr.Reviewers {
+		if r.State == ReviewStateApproved {
+			n++
+		}
+	}
+	return n
+}
+
+func (pr PullRequest) HasReviewer(userID string) bool {
+	for _, r := range pr.Reviewers {
+		if r.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ставит newID на место oldID, решение прежнего ревьювера не переносится
+func (pr *PullRequest) ReplaceReviewer(oldID, newID string, at time.Time) {
+	for i, r := range pr.Reviewers {
+		if r.UserID == oldID {
+			pr.Reviewers[i] = PullRequestReviewer{UserID: newID, State: ReviewStatePending, AssignedAt: &at}
+			return
+		}
+	}
+}
+
+func (pr *PullRequest) RemoveReviewer(userID string) {
+	reviewers := make([]PullRequestReviewer, 0, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		if r.UserID != userID {
+			reviewers = append(reviewers, r)
+		}
+	}
+	pr.Reviewers = reviewers
+}
+
+// новые ревьюверы в состоянии PENDING
+func NewReviewers(ids []string, at time.Time) []PullRequestReviewer {
+	reviewers := make([]PullRequestReviewer, 0, len(ids))
+	for _, id := range ids {
+		reviewers = append(reviewers, PullRequestReviewer{UserID: id, State: ReviewStatePending, AssignedAt: &at})
+	}
+	return reviewers
 }
 
 type PullRequestShort struct {
@@ -24,4 +211,19 @@ type PullRequestShort struct {
 	PullRequestName string            `json:"pull_request_name"`
 	AuthorID        string            `json:"author_id"`
 	Status          PullRequestStatus `json:"status"`
+	Repository      string            `json:"repository"`
+	Number          string            `json:"number"`
+}
+
+// результат переназначения ревью пользователя, например при деактивации
+type ReviewReassignment struct {
+	PullRequestID string    `json:"pull_request_id"`
+	OldReviewerID string    `json:"old_reviewer_id,omitempty"`
+	ReplacedBy    string    `json:"replaced_by,omitempty"` // пусто, если ревьювер снят без замены (allow_understaffed)
+	Error         ErrorCode `json:"error,omitempty"`       // почему переназначить не удалось
+}
+
+type ReassignmentReport struct {
+	Reassigned    []ReviewReassignment `json:"reassigned"`
+	NotReassigned []ReviewReassignment `json:"not_reassigned"`
 }
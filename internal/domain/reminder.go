@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// PENDING ревьювер OPEN PR, которому пора напомнить
+type StaleReview struct {
+	PullRequestID   string
+	PullRequestName string
+	URL             string
+	ReviewerID      string
+	Username        string
+	AssignedAt      time.Time
+}
+
+// отправленное напоминание
+type ReviewReminder struct {
+	ID            int64     `json:"id"`
+	PullRequestID string    `json:"pull_request_id"`
+	ReviewerID    string    `json:"reviewer_id"`
+	Channel       string    `json:"channel"`
+	SentAt        time.Time `json:"sent_at"`
+}
+
+// напоминание, которое не удалось доставить, повторится при следующем запуске
+type ReminderFailure struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	Error         string `json:"error"`
+}
+
+type ReminderReport struct {
+	Sent   []ReviewReminder  `json:"sent"`
+	Failed []ReminderFailure `json:"failed"`
+}
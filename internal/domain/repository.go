@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// сюда попадают PR, созданные без репозитория, и все PR до появления репозиториев
+const DefaultRepository = "default"
+
+type Repository struct {
+	Name         string                 `json:"name"`
+	TeamName     string                 `json:"team_name,omitempty"` // команда-владелец
+	ReviewPolicy RepositoryReviewPolicy `json:"review_policy"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+// настройки по умолчанию для PR репозитория, поверх политики команды автора.
+// nil - как у команды
+type RepositoryReviewPolicy struct {
+	MinReviewers *int `json:"min_reviewers,omitempty"`
+	MaxReviewers *int `json:"max_reviewers,omitempty"`
+}
+
+func (p RepositoryReviewPolicy) Validate() error {
+	if p.MinReviewers != nil && *p.MinReviewers < 0 {
+		return fmt.Errorf("%w: min_reviewers must not be negative", ErrInvalidPolicy)
+	}
+	if p.MaxReviewers != nil && *p.MaxReviewers < 0 {
+		return fmt.Errorf("%w: max_reviewers must not be negative", ErrInvalidPolicy)
+	}
+	if p.MinReviewers != nil && p.MaxReviewers != nil && *p.MinReviewers > *p.MaxReviewers {
+		return fmt.Errorf("%w: min_reviewers must not exceed max_reviewers", ErrInvalidPolicy)
+	}
+	return nil
+}
+
+// Apply подставляет заданные в репозитории лимиты в политику команды
+func (p RepositoryReviewPolicy) Apply(policy ReviewPolicy) ReviewPolicy {
+	if p.MinReviewers != nil {
+		policy.MinReviewers = *p.MinReviewers
+	}
+	if p.MaxReviewers != nil {
+		policy.MaxReviewers = *p.MaxReviewers
+	}
+	if policy.MinReviewers > policy.MaxReviewers {
+		policy.MinReviewers = policy.MaxReviewers
+	}
+	return policy
+}
+
+// pull_request_id PR, созданного через эндпоинты репозитория
+func PullRequestKey(repository, number string) string {
+	return repository + "#" + number
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// пара пользователей, которые не должны ревьюить PR друг друга.
+// Правило симметричное, хранится с UserID < OtherUserID
+type ReviewExclusion struct {
+	ID          int64     `json:"id"`
+	UserID      string    `json:"user_id"`
+	OtherUserID string    `json:"other_user_id"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// OPEN PR, где ревьювер назначен вопреки правилу: правило появилось после назначения
+type ExclusionViolation struct {
+	PullRequestID string `json:"pull_request_id"`
+	AuthorID      string `json:"author_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	ExclusionID   int64  `json:"exclusion_id"`
+}
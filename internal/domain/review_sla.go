@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// назначение ревьювера без решения на OPEN PR
+type PendingReview struct {
+	PullRequestID string
+	ReviewerID    string
+	TeamName      string // команда автора, действует ее SLA
+	AssignedAt    time.Time
+	Escalated     bool
+}
+
+// возраст назначения в рабочих часах ревьювера относительно SLA команды автора
+type ReviewAge struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	ReviewerID      string    `json:"reviewer_id"`
+	TeamName        string    `json:"team_name"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	AgeHours        float64   `json:"age_hours"`
+	ResponseHours   int       `json:"response_hours"`
+	EscalationHours int       `json:"escalation_hours,omitempty"`
+	Overdue         bool      `json:"overdue"`
+	Escalated       bool      `json:"escalated"`
+}
+
+// что было сделано по просроченному назначению
+type ReviewEscalation struct {
+	PullRequestID string           `json:"pull_request_id"`
+	ReviewerID    string           `json:"reviewer_id"`
+	AssignedAt    time.Time        `json:"assigned_at"`
+	Action        EscalationAction `json:"action"`
+	EscalatedTo   string           `json:"escalated_to,omitempty"` // пусто, если ревьювер снят без замены
+	Error         ErrorCode        `json:"error,omitempty"`        // ADD_USER не применен: пользователь не может ревьюить PR
+	CreatedAt     time.Time        `json:"created_at"`
+}
@@ -4,11 +4,25 @@ type ReviewerStat struct {
 	UserID      string `json:"user_id"`
 	Username    string `json:"username"`
 	Assignments int64  `json:"assignments"`
+	OpenReviews int64  `json:"open_reviews"`
+	Capacity    *int64 `json:"capacity"` // nil - без ограничения
+	AtCapacity  bool   `json:"at_capacity"`
+}
+
+// количество PR по статусам
+type PRCounts struct {
+	Total  int64
+	Draft  int64
+	Open   int64
+	Merged int64
+	Closed int64
 }
 
 type StatsResponse struct {
 	TotalPR   int64          `json:"total_pr"`
+	DraftPR   int64          `json:"draft_pr"`
 	OpenPR    int64          `json:"open_pr"`
 	MergedPR  int64          `json:"merged_pr"`
+	ClosedPR  int64          `json:"closed_pr"`
 	Reviewers []ReviewerStat `json:"reviewers"`
 }
@@ -1,12 +1,153 @@
 package domain
 
+import "fmt"
+
+type ReviewerStrategy string
+
+const (
+	ReviewerStrategyFirstN      ReviewerStrategy = "FIRST_N"
+	ReviewerStrategyRandom      ReviewerStrategy = "RANDOM"
+	ReviewerStrategyRoundRobin  ReviewerStrategy = "ROUND_ROBIN"
+	ReviewerStrategyLeastLoaded ReviewerStrategy = "LEAST_LOADED"
+	ReviewerStrategyWeighted    ReviewerStrategy = "WEIGHTED"
+)
+
+func (s ReviewerStrategy) IsValid() bool {
+	switch s {
+	case ReviewerStrategyFirstN, ReviewerStrategyRandom, ReviewerStrategyRoundRobin,
+		ReviewerStrategyLeastLoaded, ReviewerStrategyWeighted:
+		return true
+	}
+	return false
+}
+
 type Team struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName     string       `json:"team_name"`
+	Members      []TeamMember `json:"members"`
+	ReviewPolicy ReviewPolicy `json:"review_policy"`
+	MergePolicy  MergePolicy  `json:"merge_policy"`
+	ReviewSLA    ReviewSLA    `json:"review_sla"`
+}
+
+type EscalationAction string
+
+const (
+	EscalationActionAddUser  EscalationAction = "ADD_USER"
+	EscalationActionReassign EscalationAction = "REASSIGN"
+)
+
+func (a EscalationAction) IsValid() bool {
+	return a == EscalationActionAddUser || a == EscalationActionReassign
+}
+
+// сроки первого ответа ревьювера в рабочих часах, 0 - не отслеживаются
+type ReviewSLA struct {
+	ResponseHours    int              `json:"response_hours"`
+	EscalationHours  int              `json:"escalation_hours"` // 0 - без эскалации
+	EscalationAction EscalationAction `json:"escalation_action,omitempty"`
+	EscalationUserID string           `json:"escalation_user_id,omitempty"` // для ADD_USER
+}
+
+func (s ReviewSLA) Validate() error {
+	if s.ResponseHours < 0 || s.EscalationHours < 0 {
+		return fmt.Errorf("%w: sla hours must not be negative", ErrInvalidPolicy)
+	}
+	if s.EscalationHours == 0 {
+		return nil
+	}
+	if s.ResponseHours == 0 {
+		return fmt.Errorf("%w: escalation_hours needs response_hours", ErrInvalidPolicy)
+	}
+	if s.EscalationHours < s.ResponseHours {
+		return fmt.Errorf("%w: escalation_hours must be >= response_hours", ErrInvalidPolicy)
+	}
+	if !s.EscalationAction.IsValid() {
+		return fmt.Errorf("%w: unknown escalation_action %q", ErrInvalidPolicy, s.EscalationAction)
+	}
+	if s.EscalationAction == EscalationActionAddUser && s.EscalationUserID == "" {
+		return fmt.Errorf("%w: escalation_action ADD_USER needs escalation_user_id", ErrInvalidPolicy)
+	}
+	return nil
+}
+
+// условия merge PR авторов команды, по умолчанию без ограничений
+type MergePolicy struct {
+	RequiredApprovals   int    `json:"required_approvals"`
+	TeamLeadID          string `json:"team_lead_id,omitempty"`
+	RequireLeadApproval bool   `json:"require_lead_approval"` // нужен APPROVED от team_lead_id, кроме его собственных PR
+}
+
+func (p MergePolicy) Validate() error {
+	if p.RequiredApprovals < 0 {
+		return fmt.Errorf("%w: required_approvals must not be negative", ErrInvalidPolicy)
+	}
+	if p.RequireLeadApproval && p.TeamLeadID == "" {
+		return fmt.Errorf("%w: require_lead_approval needs team_lead_id", ErrInvalidPolicy)
+	}
+	return nil
+}
+
+// настройки назначения ревьюверов, хранятся вместе с командой
+type ReviewPolicy struct {
+	ReviewerStrategy  ReviewerStrategy `json:"reviewer_strategy"`
+	MinReviewers      int              `json:"min_reviewers"`
+	MaxReviewers      int              `json:"max_reviewers"`
+	AllowUnderstaffed bool             `json:"allow_understaffed"`
+	FallbackTeams     []string         `json:"fallback_teams"`
+
+	// лимит OPEN PR на ревью для участников без своего max_open_reviews, nil - без ограничения
+	DefaultMaxOpenReviews *int `json:"default_max_open_reviews"`
+
+	// сколько последних PR автора смотреть: кто их ревьюил, выбирается позже остальных, 0 - выключено
+	PairDiversityWindow int `json:"pair_diversity_window"`
+}
+
+func DefaultReviewPolicy() ReviewPolicy {
+	return ReviewPolicy{
+		ReviewerStrategy: ReviewerStrategyLeastLoaded,
+		MinReviewers:     0,
+		MaxReviewers:     2,
+		FallbackTeams:    []string{},
+	}
+}
+
+func (p ReviewPolicy) Validate() error {
+	if !p.ReviewerStrategy.IsValid() {
+		return fmt.Errorf("%w: unknown reviewer_strategy %q", ErrInvalidPolicy, p.ReviewerStrategy)
+	}
+	if p.MinReviewers < 0 {
+		return fmt.Errorf("%w: min_reviewers must not be negative", ErrInvalidPolicy)
+	}
+	if p.MaxReviewers < p.MinReviewers {
+		return fmt.Errorf("%w: max_reviewers must be >= min_reviewers", ErrInvalidPolicy)
+	}
+	if p.DefaultMaxOpenReviews != nil && *p.DefaultMaxOpenReviews < 0 {
+		return fmt.Errorf("%w: default_max_open_reviews must not be negative", ErrInvalidPolicy)
+	}
+	if p.PairDiversityWindow < 0 {
+		return fmt.Errorf("%w: pair_diversity_window must not be negative", ErrInvalidPolicy)
+	}
+	seen := make(map[string]struct{}, len(p.FallbackTeams))
+	for _, t := range p.FallbackTeams {
+		if t == "" {
+			return fmt.Errorf("%w: fallback team name must not be empty", ErrInvalidPolicy)
+		}
+		if _, dup := seen[t]; dup {
+			return fmt.Errorf("%w: duplicate fallback team %q", ErrInvalidPolicy, t)
+		}
+		seen[t] = struct{}{}
+	}
+	return nil
 }
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	IsActive       bool   `json:"is_active"`
+	ReviewWeight   *int   `json:"review_weight,omitempty"`
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"`
+
+	// только для чтения: текущая нагрузка и действующий лимит (свой или команды)
+	OpenReviews *int `json:"open_reviews,omitempty"`
+	Capacity    *int `json:"capacity,omitempty"`
 }
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// период отсутствия пользователя [StartsAt, EndsAt)
+type Unavailability struct {
+	ID       int64     `json:"id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
@@ -1,8 +1,42 @@
 package domain
 
+import (
+	"sort"
+	"strings"
+)
+
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID       string   `json:"user_id"`
+	Username     string   `json:"username"`
+	TeamName     string   `json:"team_name"`
+	IsActive     bool     `json:"is_active"`
+	ReviewWeight int      `json:"review_weight"`
+	Tags         []string `json:"tags"`
+
+	// nil - действует default_max_open_reviews команды
+	MaxOpenReviews *int `json:"max_open_reviews"`
+
+	// рабочее время "HH:MM" в часовом поясе TimeZone, nil - доступен в любое время
+	TimeZone  string  `json:"time_zone"`
+	WorkStart *string `json:"work_start"`
+	WorkEnd   *string `json:"work_end"`
+}
+
+// теги и метки сравниваются без учета регистра и пробелов по краям
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	res := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		res = append(res, t)
+	}
+	sort.Strings(res)
+	return res
 }
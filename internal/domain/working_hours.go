@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// разбирает время суток "HH:MM"
+func ParseClockTime(s string) (hour, minute int, err error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil || len(s) != len("15:04") {
+		return 0, 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// через сколько у пользователя начнется рабочее время, 0 — работает сейчас.
+// Рабочее время то же, что в BusinessTimeBetween: будни, внутри окна, если оно задано
+func (u User) UntilWorkingHours(now time.Time) time.Duration {
+	loc, windows := u.workWindows()
+	local := now.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	// ближайший будний день не дальше чем через неделю
+	for i := 0; i < 8; i, day = i+1, day.AddDate(0, 0, 1) {
+		if wd := day.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			continue
+		}
+		for _, w := range windows {
+			start := day.Add(time.Duration(w[0]) * time.Minute)
+			end := day.Add(time.Duration(w[1]) * time.Minute)
+			if !local.Before(end) {
+				continue
+			}
+			if !local.Before(start) {
+				return 0
+			}
+			return start.Sub(local)
+		}
+	}
+	return 0
+}
+
+// сколько рабочего времени пользователя прошло в [from, to): будние дни
+// в его часовом поясе, внутри work_start–work_end, если окно задано.
+// Ночное окно относится к календарному дню, в котором лежит каждая его часть
+func (u User) BusinessTimeBetween(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	loc, windows := u.workWindows()
+
+	var total time.Duration
+	local := from.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if wd := day.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			continue
+		}
+		for _, w := range windows {
+			start := day.Add(time.Duration(w[0]) * time.Minute)
+			end := day.Add(time.Duration(w[1]) * time.Minute)
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+	}
+	return total
+}
+
+// часовой пояс пользователя (неизвестный считается UTC) и рабочие интервалы суток
+// в минутах от полуночи по возрастанию. Без окна или с пустым окном — сутки целиком
+func (u User) workWindows() (*time.Location, [][2]int) {
+	loc, err := time.LoadLocation(u.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	windows := [][2]int{{0, 24 * 60}}
+	if u.WorkStart != nil && u.WorkEnd != nil {
+		sh, sm, errS := ParseClockTime(*u.WorkStart)
+		eh, em, errE := ParseClockTime(*u.WorkEnd)
+		if errS == nil && errE == nil {
+			start, end := sh*60+sm, eh*60+em
+			switch {
+			case start < end:
+				windows = [][2]int{{start, end}}
+			case start > end:
+				windows = [][2]int{{0, end}, {start, 24 * 60}}
+			}
+		}
+	}
+	return loc, windows
+}
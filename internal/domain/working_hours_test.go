@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// 2026-10-12 — понедельник
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func worker(tz, start, end string) User {
+	return User{UserID: "u1", TimeZone: tz, WorkStart: &start, WorkEnd: &end}
+}
+
+func TestBusinessTimeBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     User
+		from, to string
+		want     time.Duration
+	}{
+		{"no window, weekday", User{}, "2026-10-12 10:00", "2026-10-13 04:00", 18 * time.Hour},
+		{"no window, weekend skipped", User{}, "2026-10-16 12:00", "2026-10-19 12:00", 24 * time.Hour},
+		{"empty interval", User{}, "2026-10-12 10:00", "2026-10-12 10:00", 0},
+		{"reversed interval", User{}, "2026-10-12 10:00", "2026-10-12 09:00", 0},
+
+		{"inside window", worker("UTC", "09:00", "18:00"), "2026-10-12 10:00", "2026-10-12 12:30", 150 * time.Minute},
+		{"clipped to window", worker("UTC", "09:00", "18:00"), "2026-10-12 07:00", "2026-10-12 20:00", 9 * time.Hour},
+		{"several days", worker("UTC", "09:00", "18:00"), "2026-10-12 17:00", "2026-10-14 10:00", 11 * time.Hour},
+		{"friday to monday", worker("UTC", "09:00", "18:00"), "2026-10-16 17:00", "2026-10-19 10:00", 2 * time.Hour},
+		{"assigned on weekend", worker("UTC", "09:00", "18:00"), "2026-10-17 11:00", "2026-10-19 11:00", 2 * time.Hour},
+
+		// 09:00–18:00 в Москве — 06:00–15:00 UTC
+		{"time zone", worker("Europe/Moscow", "09:00", "18:00"), "2026-10-12 05:00", "2026-10-12 16:00", 9 * time.Hour},
+		{"unknown time zone is utc", worker("Mars/Base", "09:00", "18:00"), "2026-10-12 05:00", "2026-10-12 16:00", 7 * time.Hour},
+
+		// ночная смена: часть до полуночи и часть после относятся к своим дням
+		{"overnight window", worker("UTC", "22:00", "06:00"), "2026-10-12 21:00", "2026-10-13 07:00", 8 * time.Hour},
+		{"overnight into saturday", worker("UTC", "22:00", "06:00"), "2026-10-16 21:00", "2026-10-17 07:00", 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.BusinessTimeBetween(at(tt.from), at(tt.to)); got != tt.want {
+				t.Errorf("BusinessTimeBetween(%s, %s) = %s, want %s", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUntilWorkingHours(t *testing.T) {
+	tests := []struct {
+		name string
+		user User
+		now  string
+		want time.Duration
+	}{
+		{"no window, weekday", User{}, "2026-10-12 03:00", 0},
+		{"no window, saturday", User{}, "2026-10-17 12:00", 36 * time.Hour},
+
+		{"inside window", worker("UTC", "09:00", "18:00"), "2026-10-12 10:00", 0},
+		{"window start is inclusive", worker("UTC", "09:00", "18:00"), "2026-10-12 09:00", 0},
+		{"before window", worker("UTC", "09:00", "18:00"), "2026-10-12 07:30", 90 * time.Minute},
+		{"window end is exclusive", worker("UTC", "09:00", "18:00"), "2026-10-12 18:00", 15 * time.Hour},
+		{"friday evening waits for monday", worker("UTC", "09:00", "18:00"), "2026-10-16 19:00", 62 * time.Hour},
+		{"sunday inside window hours", worker("UTC", "09:00", "18:00"), "2026-10-18 10:00", 23 * time.Hour},
+
+		// 09:00–18:00 в Москве — 06:00–15:00 UTC
+		{"time zone", worker("Europe/Moscow", "09:00", "18:00"), "2026-10-12 05:00", time.Hour},
+		{"time zone weekday boundary", worker("Europe/Moscow", "09:00", "18:00"), "2026-10-16 22:00", 56 * time.Hour},
+
+		// часть ночного окна после полуночи в субботу — уже выходной, как в BusinessTimeBetween
+		{"overnight window", worker("UTC", "22:00", "06:00"), "2026-10-13 03:00", 0},
+		{"overnight friday night", worker("UTC", "22:00", "06:00"), "2026-10-16 23:00", 0},
+		{"overnight into saturday", worker("UTC", "22:00", "06:00"), "2026-10-17 03:00", 45 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.UntilWorkingHours(at(tt.now)); got != tt.want {
+				t.Errorf("UntilWorkingHours(%s) = %s, want %s", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+// пользователь работает в момент t тогда и только тогда, когда рабочее время идет
+func TestWorkingHoursConsistency(t *testing.T) {
+	users := []User{
+		{},
+		worker("UTC", "09:00", "18:00"),
+		worker("UTC", "22:00", "06:00"),
+		worker("Europe/Moscow", "09:00", "18:00"),
+		worker("America/New_York", "20:00", "04:00"),
+	}
+	from := at("2026-10-15 00:00")
+	for i, u := range users {
+		for now := from; now.Before(from.AddDate(0, 0, 5)); now = now.Add(30 * time.Minute) {
+			working := u.UntilWorkingHours(now) == 0
+			counted := u.BusinessTimeBetween(now, now.Add(time.Minute)) > 0
+			if working != counted {
+				t.Errorf("user %d at %s: working = %v, business time counted = %v", i, now, working, counted)
+			}
+
+			// до начала рабочего времени оно не идет, сразу после — идет
+			if wait := u.UntilWorkingHours(now); wait > 0 {
+				if u.BusinessTimeBetween(now, now.Add(wait)) != 0 || u.UntilWorkingHours(now.Add(wait)) != 0 {
+					t.Errorf("user %d at %s: wait %s does not end at working hours", i, now, wait)
+				}
+			}
+		}
+	}
+}
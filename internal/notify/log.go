@@ -0,0 +1,30 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogNotifier пишет уведомления в лог сервиса, канал по умолчанию
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Name() string {
+	return "log"
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, msg Notification) error {
+	n.logger.InfoContext(ctx, "notification",
+		slog.String("kind", string(msg.Kind)),
+		slog.String("user_id", msg.UserID),
+		slog.String("pull_request_id", msg.PullRequestID),
+		slog.Int("pull_requests", len(msg.PullRequests)),
+		slog.String("message", msg.Message),
+	)
+	return nil
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"context"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindStaleReview Kind = "STALE_REVIEW" // ревьювер давно не отвечает на PR
+)
+
+// Notification — сообщение пользователю, каналы доставки сами решают, как его показать.
+// PullRequestID, PullRequestName и URL — первый PR из PullRequests
+type Notification struct {
+	Kind            Kind             `json:"kind"`
+	UserID          string           `json:"user_id"`
+	Username        string           `json:"username,omitempty"`
+	PullRequestID   string           `json:"pull_request_id"`
+	PullRequestName string           `json:"pull_request_name"`
+	URL             string           `json:"url,omitempty"`
+	PullRequests    []PullRequestRef `json:"pull_requests,omitempty"`
+	Message         string           `json:"message"`
+	CreatedAt       time.Time        `json:"created_at"`
+}
+
+// PR, о котором уведомление
+type PullRequestRef struct {
+	ID   string `json:"pull_request_id"`
+	Name string `json:"pull_request_name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Notifier доставляет уведомления. Ошибка означает, что уведомление не доставлено
+// и его можно отправить повторно
+type Notifier interface {
+	// имя канала, сохраняется в истории отправок
+	Name() string
+
+	Notify(ctx context.Context, n Notification) error
+}
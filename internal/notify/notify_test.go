@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var msg = Notification{
+	Kind:            KindStaleReview,
+	UserID:          "u1",
+	Username:        "alice",
+	PullRequestID:   "pr-1",
+	PullRequestName: "Add search",
+	PullRequests:    []PullRequestRef{{ID: "pr-1", Name: "Add search"}, {ID: "pr-2", Name: "Fix login"}},
+	Message:         "2 pull requests are waiting for your review",
+	CreatedAt:       time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC),
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var got Notification
+	var contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	if err := NewWebhookNotifier(srv.URL, time.Second).Notify(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "application/json" {
+		t.Errorf("content type = %q", contentType)
+	}
+	if got.UserID != msg.UserID || got.PullRequestID != msg.PullRequestID || len(got.PullRequests) != 2 ||
+		got.Message != msg.Message || !got.CreatedAt.Equal(msg.CreatedAt) {
+		t.Errorf("body = %+v, want %+v", got, msg)
+	}
+}
+
+func TestWebhookNotifierErrors(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		timeout time.Duration
+		want    string
+	}{
+		{"non 2xx status", failing.URL, time.Second, "502"},
+		{"timeout", slow.URL, 50 * time.Millisecond, "webhook:"},
+		{"connection refused", closed.URL, time.Second, "webhook:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewWebhookNotifier(tt.url, tt.timeout).Notify(context.Background(), msg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLogNotifier(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	if n.Name() != "log" {
+		t.Errorf("name = %q, want log", n.Name())
+	}
+	if err := n.Notify(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec["kind"] != string(KindStaleReview) || rec["user_id"] != "u1" || rec["pull_request_id"] != "pr-1" ||
+		rec["pull_requests"] != float64(2) || rec["message"] != msg.Message {
+		t.Errorf("log record = %v", rec)
+	}
+}
@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// JobLocker — сессионный advisory-лок на отдельном соединении из пула,
+// держится все время выполнения задачи планировщика. Выполненные запуски
+// по расписанию отмечаются в job_runs
+type JobLocker struct {
+	pool *pgxpool.Pool
+}
+
+func NewJobLocker(pool *pgxpool.Pool) *JobLocker {
+	return &JobLocker{pool: pool}
+}
+
+func (l *JobLocker) TryLock(ctx context.Context, key string) (func(), bool, error) {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var ok bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, key).Scan(&ok); err != nil {
+		conn.Release()
+		return nil, false, err
+	}
+	if !ok {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		// контекст задачи к этому моменту может быть отменен
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if _, err := conn.Exec(ctx, `SELECT pg_advisory_unlock(hashtext($1))`, key); err != nil {
+			// лок живет, пока живо соединение: не возвращаем его в пул
+			_ = conn.Hijack().Close(ctx)
+			return
+		}
+		conn.Release()
+	}
+	return unlock, true, nil
+}
+
+func (l *JobLocker) Claim(ctx context.Context, job string, slot time.Time) (bool, error) {
+	tag, err := l.pool.Exec(ctx,
+		`INSERT INTO job_runs (job_name, slot, claimed_at)
+         VALUES ($1, $2, now())
+         ON CONFLICT (job_name) DO UPDATE
+             SET slot = EXCLUDED.slot, claimed_at = EXCLUDED.claimed_at
+             WHERE job_runs.slot < EXCLUDED.slot`,
+		job, slot,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Ownership interface {
+	//заменяет все правила новым CODEOWNERS, порядок сохраняется
+	ReplaceRules(ctx context.Context, rules []domain.OwnershipRule) error
+
+	GetRules(ctx context.Context) ([]domain.OwnershipRule, error)
+}
+
+type OwnershipRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewOwnershipRepo(pool *pgxpool.Pool) *OwnershipRepo {
+	return &OwnershipRepo{
+		pool: pool,
+	}
+}
+
+func (r *OwnershipRepo) db(ctx context.Context) querier {
+	return executor(ctx, r.pool)
+}
+
+func (r *OwnershipRepo) ReplaceRules(ctx context.Context, rules []domain.OwnershipRule) error {
+	tx, err := r.db(ctx).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM ownership_rules`); err != nil {
+		return err
+	}
+
+	for i, rule := range rules {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO ownership_rules (position, pattern, owner_teams, owner_users)
+             VALUES ($1, $2, $3, $4)`,
+			i, rule.Pattern, rule.Teams, rule.Users,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *OwnershipRepo) GetRules(ctx context.Context) ([]domain.OwnershipRule, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT pattern, owner_teams, owner_users
+         FROM ownership_rules
+         ORDER BY position`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]domain.OwnershipRule, 0)
+	for rows.Next() {
+		var rule domain.OwnershipRule
+		if err := rows.Scan(&rule.Pattern, &rule.Teams, &rule.Users); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
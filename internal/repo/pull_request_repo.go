@@ -15,17 +15,81 @@ type PullRequest interface {
 
 	GetByID(ctx context.Context, prID string) (domain.PullRequest, error)
 
+	//GetByID с блокировкой строки PR до конца транзакции, для всех изменений PR
+	GetByIDForUpdate(ctx context.Context, prID string) (domain.PullRequest, error)
+
+	//обновляет поля PR без ревьюверов
 	Update(ctx context.Context, pr domain.PullRequest) error
 
 	Exists(ctx context.Context, prID string) (bool, error)
 
-	GetByReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
+	//pull_request_id PR с номером number в репозитории, ErrNotFound если такого нет
+	GetIDByNumber(ctx context.Context, repository, number string) (string, error)
+
+	//PR, где пользователь ревьювер; repository = "" - во всех репозиториях
+	GetByReviewer(ctx context.Context, userID, repository string) ([]domain.PullRequestShort, error)
 
 	GetReviewers(ctx context.Context, prID string) ([]string, error)
 
-	ReassignReviewer(ctx context.Context, prID string, oldUserID, newReviewerID string) error
+	//fromFallback - новый ревьювер взят из запасной команды, его решение PENDING
+	ReassignReviewer(ctx context.Context, prID string, oldUserID, newReviewerID string, fromFallback bool, assignedAt time.Time) error
+
+	//заменяет ревьюверов PR (с признаком fallback и решениями) и флаг understaffed
+	SetReviewers(ctx context.Context, pr domain.PullRequest) error
+
+	//решение ревьювера, ErrNotAssigned если он не назначен на PR
+	SetReviewState(ctx context.Context, prID, reviewerID string, state domain.ReviewState, reviewedAt time.Time) error
+
+	//количество OPEN PR, на которые назначен каждый из пользователей
+	GetOpenReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error)
+
+	//сколько раз каждый ревьювер был назначен на последние window PR автора, кроме exceptPRID
+	GetRecentPairCounts(ctx context.Context, authorID, exceptPRID string, window int) (map[string]int, error)
+
+	//GetRecentPairCounts для нескольких PR одним запросом, по pull_request_id
+	GetRecentPairCountsBatch(ctx context.Context, windows []PairWindow) (map[string]map[string]int, error)
+
+	//OPEN PR, где ревьювер один из userIDs, с ревьюверами, двумя запросами.
+	//Строки PR блокируются до конца транзакции
+	GetOpenByReviewers(ctx context.Context, userIDs []string) ([]domain.PullRequest, error)
+
+	//применяет замены ревьюверов и объяснения одним батчем
+	ApplyReviewerChanges(ctx context.Context, changes []ReviewerChange, explanations []domain.AssignmentExplanation) error
+
+	//объяснение назначения /pullRequest/assignmentExplain
+	AddAssignmentExplanation(ctx context.Context, e domain.AssignmentExplanation) error
+
+	//все объяснения по PR в порядке назначения
+	GetAssignmentExplanations(ctx context.Context, prID string) ([]domain.AssignmentExplanation, error)
+
+	//PENDING ревьюверы OPEN PR с командой автора; teamName = "" - все команды
+	GetPendingReviews(ctx context.Context, teamName string) ([]domain.PendingReview, error)
+
+	//записывает эскалацию назначения, повторная для того же назначения игнорируется
+	AddEscalation(ctx context.Context, e domain.ReviewEscalation) error
+
+	//добавляет события в историю PR одним батчем
+	AddEvents(ctx context.Context, events []domain.PREvent) error
+
+	//история PR в порядке событий
+	GetEvents(ctx context.Context, prID string) ([]domain.PREvent, error)
+}
+
+// последние Window PR автора, кроме самого PullRequestID
+type PairWindow struct {
+	PullRequestID string
+	AuthorID      string
+	Window        int
+}
 
-	SetReviewers(ctx context.Context, prID string, reviewersIDs []string) error
+// замена ревьювера в PR, пустой NewReviewerID означает, что ревьювер снимается
+type ReviewerChange struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+	FromFallback  bool
+	Understaffed  bool
+	AssignedAt    time.Time
 }
 
 type PullRequestRepo struct {
@@ -38,9 +102,13 @@ func NewPullRequestRepo(pool *pgxpool.Pool) *PullRequestRepo {
 	}
 }
 
+func (r *PullRequestRepo) db(ctx context.Context) querier {
+	return executor(ctx, r.pool)
+}
+
 func (r *PullRequestRepo) Exists(ctx context.Context, prID string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx,
+	err := r.db(ctx).QueryRow(ctx,
 		`SELECT EXISTS (SELECT 1 FROM pull_requests WHERE pull_request_id = $1)`,
 		prID,
 	).Scan(&exists)
@@ -50,8 +118,23 @@ func (r *PullRequestRepo) Exists(ctx context.Context, prID string) (bool, error)
 	return exists, nil
 }
 
+func (r *PullRequestRepo) GetIDByNumber(ctx context.Context, repository, number string) (string, error) {
+	var prID string
+	err := r.db(ctx).QueryRow(ctx,
+		`SELECT pull_request_id FROM pull_requests WHERE repository = $1 AND number = $2`,
+		repository, number,
+	).Scan(&prID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrNotFound
+		}
+		return "", err
+	}
+	return prID, nil
+}
+
 func (r *PullRequestRepo) Create(ctx context.Context, pr domain.PullRequest) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := r.db(ctx).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -59,44 +142,71 @@ func (r *PullRequestRepo) Create(ctx context.Context, pr domain.PullRequest) err
 
 	_, err = tx.Exec(ctx,
 		`INSERT INTO pull_requests
-            (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
-         VALUES ($1, $2, $3, $4, $5, $6)`,
+            (pull_request_id, pull_request_name, author_id, status, created_at, merged_at, understaffed, labels, ready_at,
+             repository, source_branch, target_branch, url, description, lines_added, lines_removed, files_changed, number)
+         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
 		pr.PullRequestID,
 		pr.PullRequestName,
 		pr.AuthorID,
 		string(pr.Status),
 		pr.CreatedAt,
 		pr.MergedAt,
+		pr.Understaffed,
+		orEmpty(pr.Labels),
+		pr.ReadyAt,
+		pr.Repository,
+		pr.SourceBranch,
+		pr.TargetBranch,
+		pr.URL,
+		pr.Description,
+		pr.LinesAdded,
+		pr.LinesRemoved,
+		pr.FilesChanged,
+		pr.Number,
 	)
 	if err != nil {
 		return err
 	}
 
-	for _, reviewerID := range pr.AssignedReviewers {
-		_, err = tx.Exec(ctx,
-			`INSERT INTO pr_reviewers (pull_request_id, reviewer_id)
-             VALUES ($1, $2)`,
-			pr.PullRequestID, reviewerID,
-		)
-		if err != nil {
-			return err
-		}
+	if err := insertReviewers(ctx, tx, pr); err != nil {
+		return err
 	}
 
 	return tx.Commit(ctx)
 }
 
+// TEXT[] NOT NULL: nil пишем как пустой массив
+func orEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 func (r *PullRequestRepo) GetByID(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return r.getByID(ctx, prID, "")
+}
+
+func (r *PullRequestRepo) GetByIDForUpdate(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return r.getByID(ctx, prID, " FOR UPDATE")
+}
+
+// lock дописывается к запросу строки PR, ревьюверы читаются после блокировки
+func (r *PullRequestRepo) getByID(ctx context.Context, prID, lock string) (domain.PullRequest, error) {
 	var pr domain.PullRequest
 	var status string
 	var createdAt, mergedAt *time.Time
 
-	err := r.pool.QueryRow(ctx,
-		`SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+	err := r.db(ctx).QueryRow(ctx,
+		`SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, understaffed, labels,
+                force_merged, merge_bypassed, ready_at, closed_at,
+                repository, source_branch, target_branch, url, description, lines_added, lines_removed, files_changed, number
          FROM pull_requests
-         WHERE pull_request_id = $1`,
+         WHERE pull_request_id = $1`+lock,
 		prID,
-	).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &createdAt, &mergedAt)
+	).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &createdAt, &mergedAt, &pr.Understaffed, &pr.Labels,
+		&pr.ForceMerged, &pr.MergeBypassed, &pr.ReadyAt, &pr.ClosedAt,
+		&pr.Repository, &pr.SourceBranch, &pr.TargetBranch, &pr.URL, &pr.Description, &pr.LinesAdded, &pr.LinesRemoved, &pr.FilesChanged, &pr.Number)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.PullRequest{}, domain.ErrNotFound
@@ -108,29 +218,84 @@ func (r *PullRequestRepo) GetByID(ctx context.Context, prID string) (domain.Pull
 	pr.CreatedAt = createdAt
 	pr.MergedAt = mergedAt
 
-	reviewers, err := r.GetReviewers(ctx, prID)
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT reviewer_id, is_fallback, state, assigned_at, reviewed_at
+         FROM pr_reviewers
+         WHERE pull_request_id = $1
+         ORDER BY assigned_at, reviewer_id`,
+		prID,
+	)
 	if err != nil {
 		return domain.PullRequest{}, err
 	}
-	pr.AssignedReviewers = reviewers
+	defer rows.Close()
+
+	pr.Reviewers = make([]domain.PullRequestReviewer, 0)
+	for rows.Next() {
+		rv, fallback, err := scanReviewer(rows)
+		if err != nil {
+			return domain.PullRequest{}, err
+		}
+		pr.Reviewers = append(pr.Reviewers, rv)
+		if fallback {
+			pr.FallbackReviewers = append(pr.FallbackReviewers, rv.UserID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return domain.PullRequest{}, err
+	}
 
 	return pr, nil
 }
 
-func (r *PullRequestRepo) Update(ctx context.Context, pr domain.PullRequest) error {
-	tx, err := r.pool.Begin(ctx)
-	if err != nil {
-		return err
+func scanReviewer(row pgx.Row, dest ...any) (domain.PullRequestReviewer, bool, error) {
+	var rv domain.PullRequestReviewer
+	var state string
+	var fallback bool
+	if err := row.Scan(append(dest, &rv.UserID, &fallback, &state, &rv.AssignedAt, &rv.ReviewedAt)...); err != nil {
+		return domain.PullRequestReviewer{}, false, err
 	}
-	defer tx.Rollback(ctx)
+	rv.State = domain.ReviewState(state)
+	return rv, fallback, nil
+}
 
-	_, err = tx.Exec(ctx,
+func insertReviewers(ctx context.Context, tx pgx.Tx, pr domain.PullRequest) error {
+	fallback := make(map[string]struct{}, len(pr.FallbackReviewers))
+	for _, id := range pr.FallbackReviewers {
+		fallback[id] = struct{}{}
+	}
+
+	for _, rv := range pr.Reviewers {
+		_, isFallback := fallback[rv.UserID]
+		state := rv.State
+		if state == "" {
+			state = domain.ReviewStatePending
+		}
+		_, err := tx.Exec(ctx,
+			`INSERT INTO pr_reviewers (pull_request_id, reviewer_id, is_fallback, state, assigned_at, reviewed_at)
+             VALUES ($1, $2, $3, $4, COALESCE($5, now()), $6)`,
+			pr.PullRequestID, rv.UserID, isFallback, string(state), rv.AssignedAt, rv.ReviewedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PullRequestRepo) Update(ctx context.Context, pr domain.PullRequest) error {
+	cmdTag, err := r.db(ctx).Exec(ctx,
 		`UPDATE pull_requests
          SET pull_request_name = $2,
              author_id         = $3,
              status            = $4,
              created_at        = $5,
-             merged_at         = $6
+             merged_at         = $6,
+             understaffed      = $7,
+             force_merged      = $8,
+             merge_bypassed    = $9,
+             ready_at          = $10,
+             closed_at         = $11
          WHERE pull_request_id = $1`,
 		pr.PullRequestID,
 		pr.PullRequestName,
@@ -138,36 +303,23 @@ func (r *PullRequestRepo) Update(ctx context.Context, pr domain.PullRequest) err
 		string(pr.Status),
 		pr.CreatedAt,
 		pr.MergedAt,
+		pr.Understaffed,
+		pr.ForceMerged,
+		orEmpty(pr.MergeBypassed),
+		pr.ReadyAt,
+		pr.ClosedAt,
 	)
 	if err != nil {
 		return err
 	}
-
-	// переустанавливаем ревьюверов
-	_, err = tx.Exec(ctx,
-		`DELETE FROM pr_reviewers WHERE pull_request_id = $1`,
-		pr.PullRequestID,
-	)
-	if err != nil {
-		return err
-	}
-
-	for _, reviewerID := range pr.AssignedReviewers {
-		_, err = tx.Exec(ctx,
-			`INSERT INTO pr_reviewers (pull_request_id, reviewer_id)
-             VALUES ($1, $2)`,
-			pr.PullRequestID, reviewerID,
-		)
-		if err != nil {
-			return err
-		}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
 	}
-
-	return tx.Commit(ctx)
+	return nil
 }
 
 func (r *PullRequestRepo) GetReviewers(ctx context.Context, prID string) ([]string, error) {
-	rows, err := r.pool.Query(ctx,
+	rows, err := r.db(ctx).Query(ctx,
 		`SELECT reviewer_id
          FROM pr_reviewers
          WHERE pull_request_id = $1`,
@@ -192,41 +344,49 @@ func (r *PullRequestRepo) GetReviewers(ctx context.Context, prID string) ([]stri
 	return reviewers, nil
 }
 
-func (r *PullRequestRepo) SetReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
-	tx, err := r.pool.Begin(ctx)
+func (r *PullRequestRepo) SetReviewers(ctx context.Context, pr domain.PullRequest) error {
+	tx, err := r.db(ctx).Begin(ctx)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback(ctx)
 
+	cmdTag, err := tx.Exec(ctx,
+		`UPDATE pull_requests SET understaffed = $2 WHERE pull_request_id = $1`,
+		pr.PullRequestID, pr.Understaffed,
+	)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
 	_, err = tx.Exec(ctx,
 		`DELETE FROM pr_reviewers WHERE pull_request_id = $1`,
-		prID,
+		pr.PullRequestID,
 	)
 	if err != nil {
 		return err
 	}
 
-	for _, reviewerID := range reviewerIDs {
-		_, err = tx.Exec(ctx,
-			`INSERT INTO pr_reviewers (pull_request_id, reviewer_id)
-             VALUES ($1, $2)`,
-			prID, reviewerID,
-		)
-		if err != nil {
-			return err
-		}
+	if err := insertReviewers(ctx, tx, pr); err != nil {
+		return err
 	}
 
 	return tx.Commit(ctx)
 }
 
-func (r *PullRequestRepo) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
-	cmdTag, err := r.pool.Exec(ctx,
+func (r *PullRequestRepo) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string, fromFallback bool, assignedAt time.Time) error {
+	cmdTag, err := r.db(ctx).Exec(ctx,
 		`UPDATE pr_reviewers
-         SET reviewer_id = $3
+         SET reviewer_id = $3,
+             is_fallback = $4,
+             state       = 'PENDING',
+             assigned_at = $5,
+             reviewed_at = NULL
          WHERE pull_request_id = $1 AND reviewer_id = $2`,
-		prID, oldReviewerID, newReviewerID,
+		prID, oldReviewerID, newReviewerID, fromFallback, assignedAt,
 	)
 	if err != nil {
 		return err
@@ -237,16 +397,35 @@ func (r *PullRequestRepo) ReassignReviewer(ctx context.Context, prID, oldReviewe
 	return nil
 }
 
-func (r *PullRequestRepo) GetByReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
-	rows, err := r.pool.Query(ctx,
+func (r *PullRequestRepo) SetReviewState(ctx context.Context, prID, reviewerID string, state domain.ReviewState, reviewedAt time.Time) error {
+	cmdTag, err := r.db(ctx).Exec(ctx,
+		`UPDATE pr_reviewers
+         SET state       = $3,
+             reviewed_at = $4
+         WHERE pull_request_id = $1 AND reviewer_id = $2`,
+		prID, reviewerID, string(state), reviewedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotAssigned
+	}
+	return nil
+}
+
+func (r *PullRequestRepo) GetByReviewer(ctx context.Context, userID, repository string) ([]domain.PullRequestShort, error) {
+	rows, err := r.db(ctx).Query(ctx,
 		`SELECT pr.pull_request_id,
                 pr.pull_request_name,
                 pr.author_id,
-                pr.status
+                pr.status,
+                pr.repository,
+                pr.number
          FROM pull_requests pr
          JOIN pr_reviewers r ON r.pull_request_id = pr.pull_request_id
-         WHERE r.reviewer_id = $1`,
-		userID,
+         WHERE r.reviewer_id = $1 AND ($2 = '' OR pr.repository = $2)`,
+		userID, repository,
 	)
 	if err != nil {
 		return nil, err
@@ -257,7 +436,7 @@ func (r *PullRequestRepo) GetByReviewer(ctx context.Context, userID string) ([]d
 	for rows.Next() {
 		var item domain.PullRequestShort
 		var status string
-		if err := rows.Scan(&item.PullRequestID, &item.PullRequestName, &item.AuthorID, &status); err != nil {
+		if err := rows.Scan(&item.PullRequestID, &item.PullRequestName, &item.AuthorID, &status, &item.Repository, &item.Number); err != nil {
 			return nil, err
 		}
 		item.Status = domain.PullRequestStatus(status)
@@ -268,3 +447,395 @@ func (r *PullRequestRepo) GetByReviewer(ctx context.Context, userID string) ([]d
 	}
 	return result, nil
 }
+
+func (r *PullRequestRepo) GetOpenReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error) {
+	load := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return load, nil
+	}
+
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT r.reviewer_id, COUNT(*)
+         FROM pr_reviewers r
+         JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+         WHERE pr.status = 'OPEN' AND r.reviewer_id = ANY($1)
+         GROUP BY r.reviewer_id`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var cnt int
+		if err := rows.Scan(&id, &cnt); err != nil {
+			return nil, err
+		}
+		load[id] = cnt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return load, nil
+}
+
+func (r *PullRequestRepo) GetRecentPairCounts(ctx context.Context, authorID, exceptPRID string, window int) (map[string]int, error) {
+	counts := make(map[string]int)
+	if window <= 0 {
+		return counts, nil
+	}
+
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT r.reviewer_id, COUNT(*)
+         FROM pr_reviewers r
+         JOIN (SELECT pull_request_id
+               FROM pull_requests
+               WHERE author_id = $1 AND pull_request_id <> $2
+               ORDER BY created_at DESC, pull_request_id DESC
+               LIMIT $3) recent ON recent.pull_request_id = r.pull_request_id
+         GROUP BY r.reviewer_id`,
+		authorID, exceptPRID, window,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var cnt int
+		if err := rows.Scan(&id, &cnt); err != nil {
+			return nil, err
+		}
+		counts[id] = cnt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *PullRequestRepo) GetRecentPairCountsBatch(ctx context.Context, windows []PairWindow) (map[string]map[string]int, error) {
+	counts := make(map[string]map[string]int, len(windows))
+	prIDs := make([]string, 0, len(windows))
+	authorIDs := make([]string, 0, len(windows))
+	sizes := make([]int32, 0, len(windows))
+	for _, w := range windows {
+		counts[w.PullRequestID] = make(map[string]int)
+		if w.Window <= 0 {
+			continue
+		}
+		prIDs = append(prIDs, w.PullRequestID)
+		authorIDs = append(authorIDs, w.AuthorID)
+		sizes = append(sizes, int32(w.Window))
+	}
+	if len(prIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT q.pull_request_id, r.reviewer_id, COUNT(*)
+         FROM unnest($1::text[], $2::text[], $3::int[]) AS q(pull_request_id, author_id, size)
+         CROSS JOIN LATERAL (SELECT p.pull_request_id
+                             FROM pull_requests p
+                             WHERE p.author_id = q.author_id AND p.pull_request_id <> q.pull_request_id
+                             ORDER BY p.created_at DESC, p.pull_request_id DESC
+                             LIMIT q.size) recent
+         JOIN pr_reviewers r ON r.pull_request_id = recent.pull_request_id
+         GROUP BY q.pull_request_id, r.reviewer_id`,
+		prIDs, authorIDs, sizes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID, id string
+		var cnt int
+		if err := rows.Scan(&prID, &id, &cnt); err != nil {
+			return nil, err
+		}
+		counts[prID][id] = cnt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *PullRequestRepo) AddAssignmentExplanation(ctx context.Context, e domain.AssignmentExplanation) error {
+	var replaced *string
+	if e.ReplacedReviewerID != "" {
+		replaced = &e.ReplacedReviewerID
+	}
+
+	_, err := r.db(ctx).Exec(ctx,
+		`INSERT INTO assignment_explanations (pull_request_id, action, replaced_reviewer_id, created_at, candidates)
+         VALUES ($1, $2, $3, $4, $5)`,
+		e.PullRequestID, string(e.Action), replaced, e.CreatedAt, e.Candidates,
+	)
+	return err
+}
+
+func (r *PullRequestRepo) GetAssignmentExplanations(ctx context.Context, prID string) ([]domain.AssignmentExplanation, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT pull_request_id, action, replaced_reviewer_id, created_at, candidates
+         FROM assignment_explanations
+         WHERE pull_request_id = $1
+         ORDER BY id`,
+		prID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]domain.AssignmentExplanation, 0)
+	for rows.Next() {
+		var e domain.AssignmentExplanation
+		var action string
+		var replaced *string
+		if err := rows.Scan(&e.PullRequestID, &action, &replaced, &e.CreatedAt, &e.Candidates); err != nil {
+			return nil, err
+		}
+		e.Action = domain.AssignmentAction(action)
+		if replaced != nil {
+			e.ReplacedReviewerID = *replaced
+		}
+		res = append(res, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *PullRequestRepo) GetOpenByReviewers(ctx context.Context, userIDs []string) ([]domain.PullRequest, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status,
+                pr.created_at, pr.merged_at, pr.understaffed, pr.labels
+         FROM pull_requests pr
+         WHERE pr.status = 'OPEN'
+           AND EXISTS (SELECT 1 FROM pr_reviewers r
+                       WHERE r.pull_request_id = pr.pull_request_id AND r.reviewer_id = ANY($1))
+         ORDER BY pr.pull_request_id
+         FOR UPDATE`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prs := make([]domain.PullRequest, 0)
+	index := make(map[string]int)
+	ids := make([]string, 0)
+	for rows.Next() {
+		var pr domain.PullRequest
+		var status string
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status,
+			&pr.CreatedAt, &pr.MergedAt, &pr.Understaffed, &pr.Labels); err != nil {
+			return nil, err
+		}
+		pr.Status = domain.PullRequestStatus(status)
+		index[pr.PullRequestID] = len(prs)
+		ids = append(ids, pr.PullRequestID)
+		prs = append(prs, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return prs, nil
+	}
+
+	reviewerRows, err := r.db(ctx).Query(ctx,
+		`SELECT pull_request_id, reviewer_id, is_fallback, state, assigned_at, reviewed_at
+         FROM pr_reviewers
+         WHERE pull_request_id = ANY($1)
+         ORDER BY assigned_at, reviewer_id`,
+		ids,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer reviewerRows.Close()
+
+	for reviewerRows.Next() {
+		var prID string
+		rv, fallback, err := scanReviewer(reviewerRows, &prID)
+		if err != nil {
+			return nil, err
+		}
+		pr := &prs[index[prID]]
+		pr.Reviewers = append(pr.Reviewers, rv)
+		if fallback {
+			pr.FallbackReviewers = append(pr.FallbackReviewers, rv.UserID)
+		}
+	}
+	if err := reviewerRows.Err(); err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
+func (r *PullRequestRepo) ApplyReviewerChanges(ctx context.Context, changes []ReviewerChange, explanations []domain.AssignmentExplanation) error {
+	batch := &pgx.Batch{}
+	for _, c := range changes {
+		if c.NewReviewerID == "" {
+			batch.Queue(
+				`DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND reviewer_id = $2`,
+				c.PullRequestID, c.OldReviewerID,
+			)
+		} else {
+			batch.Queue(
+				`UPDATE pr_reviewers
+                 SET reviewer_id = $3,
+                     is_fallback = $4,
+                     state       = 'PENDING',
+                     assigned_at = $5,
+                     reviewed_at = NULL
+                 WHERE pull_request_id = $1 AND reviewer_id = $2`,
+				c.PullRequestID, c.OldReviewerID, c.NewReviewerID, c.FromFallback, c.AssignedAt,
+			)
+		}
+		batch.Queue(
+			`UPDATE pull_requests SET understaffed = $2 WHERE pull_request_id = $1`,
+			c.PullRequestID, c.Understaffed,
+		)
+	}
+	for _, e := range explanations {
+		batch.Queue(
+			`INSERT INTO assignment_explanations (pull_request_id, action, replaced_reviewer_id, created_at, candidates)
+             VALUES ($1, $2, $3, $4, $5)`,
+			e.PullRequestID, string(e.Action), e.ReplacedReviewerID, e.CreatedAt, e.Candidates,
+		)
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	br := r.db(ctx).SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := 0; i < batch.Len(); i++ {
+		if _, err := br.Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PullRequestRepo) GetPendingReviews(ctx context.Context, teamName string) ([]domain.PendingReview, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT r.pull_request_id, r.reviewer_id, a.team_name, r.assigned_at,
+                EXISTS (SELECT 1
+                        FROM review_escalations e
+                        WHERE e.pull_request_id = r.pull_request_id
+                          AND e.reviewer_id = r.reviewer_id
+                          AND e.assigned_at = r.assigned_at)
+         FROM pr_reviewers r
+         JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+         JOIN users a ON a.user_id = pr.author_id
+         WHERE pr.status = 'OPEN' AND r.state = 'PENDING'
+           AND a.team_name IS NOT NULL AND ($1 = '' OR a.team_name = $1)
+         ORDER BY r.assigned_at, r.pull_request_id, r.reviewer_id`,
+		teamName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]domain.PendingReview, 0)
+	for rows.Next() {
+		var p domain.PendingReview
+		if err := rows.Scan(&p.PullRequestID, &p.ReviewerID, &p.TeamName, &p.AssignedAt, &p.Escalated); err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *PullRequestRepo) AddEscalation(ctx context.Context, e domain.ReviewEscalation) error {
+	var escalatedTo *string
+	if e.EscalatedTo != "" {
+		escalatedTo = &e.EscalatedTo
+	}
+
+	_, err := r.db(ctx).Exec(ctx,
+		`INSERT INTO review_escalations (pull_request_id, reviewer_id, assigned_at, action, escalated_to, error, created_at)
+         VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+         ON CONFLICT (pull_request_id, reviewer_id, assigned_at) DO NOTHING`,
+		e.PullRequestID, e.ReviewerID, e.AssignedAt, string(e.Action), escalatedTo, string(e.Error), e.CreatedAt,
+	)
+	return err
+}
+
+func (r *PullRequestRepo) AddEvents(ctx context.Context, events []domain.PREvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, e := range events {
+		batch.Queue(
+			`INSERT INTO pr_events (pull_request_id, type, reviewer_id, old_reviewer_id, new_reviewer_id, status, state, reason, created_at)
+             VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), $9)`,
+			e.PullRequestID, string(e.Type), e.ReviewerID, e.OldReviewerID, e.NewReviewerID,
+			string(e.Status), string(e.State), string(e.Reason), e.CreatedAt,
+		)
+	}
+
+	br := r.db(ctx).SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := 0; i < batch.Len(); i++ {
+		if _, err := br.Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PullRequestRepo) GetEvents(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT id, pull_request_id, type,
+                COALESCE(reviewer_id, ''), COALESCE(old_reviewer_id, ''), COALESCE(new_reviewer_id, ''),
+                COALESCE(status, ''), COALESCE(state, ''), COALESCE(reason, ''), created_at
+         FROM pr_events
+         WHERE pull_request_id = $1
+         ORDER BY created_at, id`,
+		prID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]domain.PREvent, 0)
+	for rows.Next() {
+		var e domain.PREvent
+		var typ, status, state, reason string
+		if err := rows.Scan(&e.ID, &e.PullRequestID, &typ, &e.ReviewerID, &e.OldReviewerID, &e.NewReviewerID,
+			&status, &state, &reason, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Type = domain.PREventType(typ)
+		e.Status = domain.PullRequestStatus(status)
+		e.State = domain.ReviewState(state)
+		e.Reason = domain.PREventReason(reason)
+		res = append(res, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"context"
+	"pr-reviewer-service/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Reminder interface {
+	//PENDING ревьюверы OPEN PR, назначенные не позже assignedBefore,
+	//которым не напоминали ни о каком PR после remindedAfter
+	GetStale(ctx context.Context, assignedBefore, remindedAfter time.Time) ([]domain.StaleReview, error)
+
+	Create(ctx context.Context, r domain.ReviewReminder) (domain.ReviewReminder, error)
+
+	//история напоминаний, новые первыми; пустой фильтр не ограничивает
+	List(ctx context.Context, prID, reviewerID string) ([]domain.ReviewReminder, error)
+}
+
+type ReminderRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewReminderRepo(pool *pgxpool.Pool) *ReminderRepo {
+	return &ReminderRepo{
+		pool: pool,
+	}
+}
+
+func (r *ReminderRepo) db(ctx context.Context) querier {
+	return executor(ctx, r.pool)
+}
+
+func (r *ReminderRepo) GetStale(ctx context.Context, assignedBefore, remindedAfter time.Time) ([]domain.StaleReview, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT pr.pull_request_id, pr.pull_request_name, pr.url, u.user_id, u.username, rv.assigned_at
+         FROM pr_reviewers rv
+         JOIN pull_requests pr ON pr.pull_request_id = rv.pull_request_id
+         JOIN users u ON u.user_id = rv.reviewer_id
+         WHERE pr.status = 'OPEN' AND rv.state = 'PENDING' AND u.is_active
+           AND rv.assigned_at <= $1
+           AND NOT EXISTS (SELECT 1
+                           FROM review_reminders rr
+                           WHERE rr.reviewer_id = rv.reviewer_id
+                             AND rr.sent_at > $2)
+         ORDER BY rv.assigned_at, pr.pull_request_id, u.user_id`,
+		assignedBefore, remindedAfter,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]domain.StaleReview, 0)
+	for rows.Next() {
+		var s domain.StaleReview
+		if err := rows.Scan(&s.PullRequestID, &s.PullRequestName, &s.URL, &s.ReviewerID, &s.Username, &s.AssignedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *ReminderRepo) Create(ctx context.Context, rem domain.ReviewReminder) (domain.ReviewReminder, error) {
+	err := r.db(ctx).QueryRow(ctx,
+		`INSERT INTO review_reminders (pull_request_id, reviewer_id, channel, sent_at)
+         VALUES ($1, $2, $3, $4)
+         RETURNING id`,
+		rem.PullRequestID, rem.ReviewerID, rem.Channel, rem.SentAt,
+	).Scan(&rem.ID)
+	if err != nil {
+		return domain.ReviewReminder{}, err
+	}
+	return rem, nil
+}
+
+func (r *ReminderRepo) List(ctx context.Context, prID, reviewerID string) ([]domain.ReviewReminder, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT id, pull_request_id, reviewer_id, channel, sent_at
+         FROM review_reminders
+         WHERE ($1 = '' OR pull_request_id = $1) AND ($2 = '' OR reviewer_id = $2)
+         ORDER BY sent_at DESC, id DESC`,
+		prID, reviewerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]domain.ReviewReminder, 0)
+	for rows.Next() {
+		var rem domain.ReviewReminder
+		if err := rows.Scan(&rem.ID, &rem.PullRequestID, &rem.ReviewerID, &rem.Channel, &rem.SentAt); err != nil {
+			return nil, err
+		}
+		res = append(res, rem)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository interface {
+	//ErrRepositoryExists, если репозиторий с таким именем уже есть
+	Create(ctx context.Context, r domain.Repository) (domain.Repository, error)
+
+	//ErrRepositoryNotFound, если репозитория нет
+	GetByName(ctx context.Context, name string) (domain.Repository, error)
+
+	List(ctx context.Context) ([]domain.Repository, error)
+}
+
+type RepositoryRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepositoryRepo(pool *pgxpool.Pool) *RepositoryRepo {
+	return &RepositoryRepo{
+		pool: pool,
+	}
+}
+
+func (r *RepositoryRepo) db(ctx context.Context) querier {
+	return executor(ctx, r.pool)
+}
+
+func (r *RepositoryRepo) Create(ctx context.Context, repository domain.Repository) (domain.Repository, error) {
+	err := r.db(ctx).QueryRow(ctx,
+		`INSERT INTO repositories (name, team_name, min_reviewers, max_reviewers)
+         VALUES ($1, NULLIF($2, ''), $3, $4)
+         ON CONFLICT (name) DO NOTHING
+         RETURNING created_at`,
+		repository.Name, repository.TeamName, repository.ReviewPolicy.MinReviewers, repository.ReviewPolicy.MaxReviewers,
+	).Scan(&repository.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Repository{}, domain.ErrRepositoryExists
+		}
+		return domain.Repository{}, err
+	}
+	return repository, nil
+}
+
+func (r *RepositoryRepo) GetByName(ctx context.Context, name string) (domain.Repository, error) {
+	repository, err := scanRepository(r.db(ctx).QueryRow(ctx,
+		`SELECT name, COALESCE(team_name, ''), min_reviewers, max_reviewers, created_at
+         FROM repositories
+         WHERE name = $1`,
+		name,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Repository{}, domain.ErrRepositoryNotFound
+		}
+		return domain.Repository{}, err
+	}
+	return repository, nil
+}
+
+func (r *RepositoryRepo) List(ctx context.Context) ([]domain.Repository, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT name, COALESCE(team_name, ''), min_reviewers, max_reviewers, created_at
+         FROM repositories
+         ORDER BY name`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	repositories := make([]domain.Repository, 0)
+	for rows.Next() {
+		repository, err := scanRepository(rows)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, repository)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return repositories, nil
+}
+
+func scanRepository(row pgx.Row) (domain.Repository, error) {
+	var repository domain.Repository
+	err := row.Scan(&repository.Name, &repository.TeamName,
+		&repository.ReviewPolicy.MinReviewers, &repository.ReviewPolicy.MaxReviewers, &repository.CreatedAt)
+	return repository, err
+}
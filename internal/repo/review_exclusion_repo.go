@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReviewExclusion interface {
+	//ErrExclusionExists, если такая пара уже есть. Пара должна быть упорядочена: UserID < OtherUserID
+	Create(ctx context.Context, e domain.ReviewExclusion) (domain.ReviewExclusion, error)
+
+	//правила, где участвует userID, "" - все правила
+	List(ctx context.Context, userID string) ([]domain.ReviewExclusion, error)
+
+	//удаляет правило и возвращает его, ErrNotFound если такого нет
+	Delete(ctx context.Context, id int64) (domain.ReviewExclusion, error)
+
+	//для каждого из userIDs — с кем он не должен ревьюить друг друга
+	GetExcludedFor(ctx context.Context, userIDs []string) (map[string][]string, error)
+
+	//OPEN PR, где ревьювер и автор — пара из правил; exclusionID = 0 - по всем правилам
+	GetViolations(ctx context.Context, exclusionID int64) ([]domain.ExclusionViolation, error)
+}
+
+type ReviewExclusionRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewReviewExclusionRepo(pool *pgxpool.Pool) *ReviewExclusionRepo {
+	return &ReviewExclusionRepo{
+		pool: pool,
+	}
+}
+
+func (r *ReviewExclusionRepo) db(ctx context.Context) querier {
+	return executor(ctx, r.pool)
+}
+
+func (r *ReviewExclusionRepo) Create(ctx context.Context, e domain.ReviewExclusion) (domain.ReviewExclusion, error) {
+	err := r.db(ctx).QueryRow(ctx,
+		`INSERT INTO review_exclusions (user_id, other_user_id, reason)
+         VALUES ($1, $2, $3)
+         ON CONFLICT (user_id, other_user_id) DO NOTHING
+         RETURNING id, created_at`,
+		e.UserID, e.OtherUserID, e.Reason,
+	).Scan(&e.ID, &e.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ReviewExclusion{}, domain.ErrExclusionExists
+		}
+		return domain.ReviewExclusion{}, err
+	}
+	return e, nil
+}
+
+func (r *ReviewExclusionRepo) List(ctx context.Context, userID string) ([]domain.ReviewExclusion, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT id, user_id, other_user_id, reason, created_at
+         FROM review_exclusions
+         WHERE $1 = '' OR user_id = $1 OR other_user_id = $1
+         ORDER BY id`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exclusions := make([]domain.ReviewExclusion, 0)
+	for rows.Next() {
+		var e domain.ReviewExclusion
+		if err := rows.Scan(&e.ID, &e.UserID, &e.OtherUserID, &e.Reason, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		exclusions = append(exclusions, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return exclusions, nil
+}
+
+func (r *ReviewExclusionRepo) Delete(ctx context.Context, id int64) (domain.ReviewExclusion, error) {
+	var e domain.ReviewExclusion
+	err := r.db(ctx).QueryRow(ctx,
+		`DELETE FROM review_exclusions
+         WHERE id = $1
+         RETURNING id, user_id, other_user_id, reason, created_at`,
+		id,
+	).Scan(&e.ID, &e.UserID, &e.OtherUserID, &e.Reason, &e.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ReviewExclusion{}, domain.ErrNotFound
+		}
+		return domain.ReviewExclusion{}, err
+	}
+	return e, nil
+}
+
+func (r *ReviewExclusionRepo) GetExcludedFor(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	excluded := make(map[string][]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return excluded, nil
+	}
+
+	requested := make(map[string]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		requested[id] = struct{}{}
+	}
+
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT user_id, other_user_id
+         FROM review_exclusions
+         WHERE user_id = ANY($1) OR other_user_id = ANY($1)`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a, b string
+		if err := rows.Scan(&a, &b); err != nil {
+			return nil, err
+		}
+		if _, ok := requested[a]; ok {
+			excluded[a] = append(excluded[a], b)
+		}
+		if _, ok := requested[b]; ok {
+			excluded[b] = append(excluded[b], a)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return excluded, nil
+}
+
+func (r *ReviewExclusionRepo) GetViolations(ctx context.Context, exclusionID int64) ([]domain.ExclusionViolation, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT pr.pull_request_id, pr.author_id, r.reviewer_id, e.id
+         FROM pull_requests pr
+         JOIN pr_reviewers r ON r.pull_request_id = pr.pull_request_id
+         JOIN review_exclusions e
+           ON (e.user_id = pr.author_id AND e.other_user_id = r.reviewer_id)
+           OR (e.other_user_id = pr.author_id AND e.user_id = r.reviewer_id)
+         WHERE pr.status = 'OPEN' AND ($1 = 0 OR e.id = $1)
+         ORDER BY pr.pull_request_id, r.reviewer_id`,
+		exclusionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	violations := make([]domain.ExclusionViolation, 0)
+	for rows.Next() {
+		var v domain.ExclusionViolation
+		if err := rows.Scan(&v.PullRequestID, &v.AuthorID, &v.ReviewerID, &v.ExclusionID); err != nil {
+			return nil, err
+		}
+		violations = append(violations, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return violations, nil
+}
@@ -9,8 +9,11 @@ import (
 )
 
 type Stats interface {
-	GetPRCounts(ctx context.Context) (total, open, merged int64, err error)
-	GetReviewerStats(ctx context.Context) ([]domain.ReviewerStat, error)
+	//repository = "" - по всем репозиториям
+	GetPRCounts(ctx context.Context, repository string) (domain.PRCounts, error)
+
+	//назначения и OPEN ревью считаются в repository, at_capacity - по всем репозиториям
+	GetReviewerStats(ctx context.Context, repository string) ([]domain.ReviewerStat, error)
 }
 
 type StatsRepo struct {
@@ -21,40 +24,48 @@ func NewStatsRepo(pool *pgxpool.Pool) *StatsRepo {
 	return &StatsRepo{pool: pool}
 }
 
-func (r *StatsRepo) GetPRCounts(ctx context.Context) (total, open, merged int64, err error) {
-	const totalQuery = `SELECT COUNT(*) FROM pull_requests;`
-
-	if err = r.pool.QueryRow(ctx, totalQuery).Scan(&total); err != nil {
-		return 0, 0, 0, fmt.Errorf("GetPRCounts total: %w", err)
-	}
+func (r *StatsRepo) db(ctx context.Context) querier {
+	return executor(ctx, r.pool)
+}
 
-	const statusQuery = `
+func (r *StatsRepo) GetPRCounts(ctx context.Context, repository string) (domain.PRCounts, error) {
+	const query = `
 SELECT 
-  COUNT(*) FILTER (WHERE status = 'OPEN')  AS open_pr,
-  COUNT(*) FILTER (WHERE status = 'MERGED') AS merged_pr
-FROM pull_requests;
+  COUNT(*)                                  AS total_pr,
+  COUNT(*) FILTER (WHERE status = 'DRAFT')  AS draft_pr,
+  COUNT(*) FILTER (WHERE status = 'OPEN')   AS open_pr,
+  COUNT(*) FILTER (WHERE status = 'MERGED') AS merged_pr,
+  COUNT(*) FILTER (WHERE status = 'CLOSED') AS closed_pr
+FROM pull_requests
+WHERE $1 = '' OR repository = $1;
 `
 
-	if err = r.pool.QueryRow(ctx, statusQuery).Scan(&open, &merged); err != nil {
-		return 0, 0, 0, fmt.Errorf("GetPRCounts by status: %w", err)
+	var c domain.PRCounts
+	if err := r.db(ctx).QueryRow(ctx, query, repository).Scan(&c.Total, &c.Draft, &c.Open, &c.Merged, &c.Closed); err != nil {
+		return domain.PRCounts{}, fmt.Errorf("GetPRCounts: %w", err)
 	}
 
-	return total, open, merged, nil
+	return c, nil
 }
 
-func (r *StatsRepo) GetReviewerStats(ctx context.Context) ([]domain.ReviewerStat, error) {
+func (r *StatsRepo) GetReviewerStats(ctx context.Context, repository string) ([]domain.ReviewerStat, error) {
 	const query = `
 SELECT 
   u.user_id,
   u.username,
-  COUNT(prr.pull_request_id) AS assignments
+  COUNT(prr.pull_request_id) FILTER (WHERE $1 = '' OR pr.repository = $1) AS assignments,
+  COUNT(prr.pull_request_id) FILTER (WHERE pr.status = 'OPEN' AND ($1 = '' OR pr.repository = $1)) AS open_reviews,
+  COUNT(prr.pull_request_id) FILTER (WHERE pr.status = 'OPEN') AS open_reviews_total,
+  COALESCE(u.max_open_reviews, t.default_max_open_reviews) AS capacity
 FROM users u
+LEFT JOIN teams t ON t.team_name = u.team_name
 LEFT JOIN pr_reviewers prr ON prr.reviewer_id = u.user_id
-GROUP BY u.user_id, u.username
+LEFT JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+GROUP BY u.user_id, u.username, u.max_open_reviews, t.default_max_open_reviews
 ORDER BY assignments DESC;
 `
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := r.db(ctx).Query(ctx, query, repository)
 	if err != nil {
 		return nil, fmt.Errorf("GetReviewerStats query: %w", err)
 	}
@@ -64,9 +75,11 @@ ORDER BY assignments DESC;
 
 	for rows.Next() {
 		var s domain.ReviewerStat
-		if err := rows.Scan(&s.UserID, &s.Username, &s.Assignments); err != nil {
+		var openTotal int64
+		if err := rows.Scan(&s.UserID, &s.Username, &s.Assignments, &s.OpenReviews, &openTotal, &s.Capacity); err != nil {
 			return nil, fmt.Errorf("GetReviewerStats scan: %w", err)
 		}
+		s.AtCapacity = s.Capacity != nil && openTotal >= *s.Capacity
 		res = append(res, s)
 	}
 
@@ -16,6 +16,26 @@ type Team interface {
 
 	//в целом необязательный метод, создал для проверки команды, чтобы не тянуть еще и участников
 	Exists(ctx context.Context, teamName string) (bool, error)
+
+	//настройки назначения ревьюверов команды
+	GetReviewPolicy(ctx context.Context, teamName string) (domain.ReviewPolicy, error)
+
+	UpdateReviewPolicy(ctx context.Context, teamName string, policy domain.ReviewPolicy) error
+
+	//условия merge PR авторов команды
+	GetMergePolicy(ctx context.Context, teamName string) (domain.MergePolicy, error)
+
+	UpdateMergePolicy(ctx context.Context, teamName string, policy domain.MergePolicy) error
+
+	//сроки ответа ревьюверов на PR авторов команды
+	GetReviewSLA(ctx context.Context, teamName string) (domain.ReviewSLA, error)
+
+	UpdateReviewSLA(ctx context.Context, teamName string, sla domain.ReviewSLA) error
+
+	//последний выбранный round-robin ревьювер, "" если выбора еще не было
+	GetRoundRobinCursor(ctx context.Context, teamName string) (string, error)
+
+	SetRoundRobinCursor(ctx context.Context, teamName string, userID string) error
 }
 
 type TeamRepo struct {
@@ -28,17 +48,45 @@ func NewTeamRepo(pool *pgxpool.Pool) *TeamRepo {
 	}
 }
 
+func (r *TeamRepo) db(ctx context.Context) querier {
+	return executor(ctx, r.pool)
+}
+
 func (r *TeamRepo) Create(ctx context.Context, team domain.Team) error {
-	_, err := r.pool.Exec(ctx,
-		`INSERT INTO teams (team_name) VALUES ($1)`,
+	tx, err := r.db(ctx).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	p := team.ReviewPolicy
+	_, err = tx.Exec(ctx,
+		`INSERT INTO teams
+            (team_name, reviewer_strategy, min_reviewers, max_reviewers, allow_understaffed, default_max_open_reviews,
+             pair_diversity_window)
+         VALUES ($1, $2, $3, $4, $5, $6, $7)`,
 		team.TeamName,
+		string(p.ReviewerStrategy),
+		p.MinReviewers,
+		p.MaxReviewers,
+		p.AllowUnderstaffed,
+		p.DefaultMaxOpenReviews,
+		p.PairDiversityWindow,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if err := replaceFallbacks(ctx, tx, team.TeamName, p.FallbackTeams); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *TeamRepo) Exists(ctx context.Context, teamName string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx,
+	err := r.db(ctx).QueryRow(ctx,
 		`SELECT EXISTS (SELECT 1 FROM teams WHERE team_name = $1)`,
 		teamName,
 	).Scan(&exists)
@@ -50,23 +98,29 @@ func (r *TeamRepo) Exists(ctx context.Context, teamName string) (bool, error) {
 }
 
 func (r *TeamRepo) GetByName(ctx context.Context, teamName string) (domain.Team, error) {
-	var name string
-	err := r.pool.QueryRow(ctx,
-		`SELECT team_name FROM teams WHERE team_name = $1`,
-		teamName,
-	).Scan(&name)
+	policy, err := r.GetReviewPolicy(ctx, teamName)
+	if err != nil {
+		return domain.Team{}, err
+	}
+	mergePolicy, err := r.GetMergePolicy(ctx, teamName)
+	if err != nil {
+		return domain.Team{}, err
+	}
+	sla, err := r.GetReviewSLA(ctx, teamName)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return domain.Team{}, domain.ErrNotFound
-		}
-
 		return domain.Team{}, err
 	}
 
-	rows, err := r.pool.Query(ctx,
-		`SELECT user_id, username, is_active
-		FROM users
-		WHERE team_name = $1`,
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT u.user_id, u.username, u.is_active, u.review_weight, u.max_open_reviews,
+                COALESCE(u.max_open_reviews, t.default_max_open_reviews),
+                (SELECT COUNT(*)
+                 FROM pr_reviewers r
+                 JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+                 WHERE r.reviewer_id = u.user_id AND pr.status = 'OPEN')
+		FROM users u
+		JOIN teams t ON t.team_name = u.team_name
+		WHERE u.team_name = $1`,
 		teamName,
 	)
 
@@ -79,9 +133,12 @@ func (r *TeamRepo) GetByName(ctx context.Context, teamName string) (domain.Team,
 	members := make([]domain.TeamMember, 0)
 	for rows.Next() {
 		var m domain.TeamMember
-		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive); err != nil {
+		var weight, openReviews int
+		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive, &weight, &m.MaxOpenReviews, &m.Capacity, &openReviews); err != nil {
 			return domain.Team{}, err
 		}
+		m.ReviewWeight = &weight
+		m.OpenReviews = &openReviews
 		members = append(members, m)
 	}
 
@@ -90,7 +147,239 @@ func (r *TeamRepo) GetByName(ctx context.Context, teamName string) (domain.Team,
 	}
 
 	return domain.Team{
-		TeamName: name,
-		Members:  members,
+		TeamName:     teamName,
+		Members:      members,
+		ReviewPolicy: policy,
+		MergePolicy:  mergePolicy,
+		ReviewSLA:    sla,
 	}, nil
 }
+
+func (r *TeamRepo) GetReviewPolicy(ctx context.Context, teamName string) (domain.ReviewPolicy, error) {
+	var p domain.ReviewPolicy
+	var strategy string
+	err := r.db(ctx).QueryRow(ctx,
+		`SELECT reviewer_strategy, min_reviewers, max_reviewers, allow_understaffed, default_max_open_reviews,
+                pair_diversity_window
+         FROM teams
+         WHERE team_name = $1`,
+		teamName,
+	).Scan(&strategy, &p.MinReviewers, &p.MaxReviewers, &p.AllowUnderstaffed, &p.DefaultMaxOpenReviews,
+		&p.PairDiversityWindow)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ReviewPolicy{}, domain.ErrNotFound
+		}
+		return domain.ReviewPolicy{}, err
+	}
+	p.ReviewerStrategy = domain.ReviewerStrategy(strategy)
+
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT fallback_team
+         FROM team_fallbacks
+         WHERE team_name = $1
+         ORDER BY position`,
+		teamName,
+	)
+	if err != nil {
+		return domain.ReviewPolicy{}, err
+	}
+	defer rows.Close()
+
+	p.FallbackTeams = make([]string, 0)
+	for rows.Next() {
+		var t string
+		if err := rows.Scan(&t); err != nil {
+			return domain.ReviewPolicy{}, err
+		}
+		p.FallbackTeams = append(p.FallbackTeams, t)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.ReviewPolicy{}, err
+	}
+
+	return p, nil
+}
+
+func (r *TeamRepo) UpdateReviewPolicy(ctx context.Context, teamName string, policy domain.ReviewPolicy) error {
+	tx, err := r.db(ctx).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	cmdTag, err := tx.Exec(ctx,
+		`UPDATE teams
+         SET reviewer_strategy  = $2,
+             min_reviewers      = $3,
+             max_reviewers      = $4,
+             allow_understaffed = $5,
+             default_max_open_reviews = $6,
+             pair_diversity_window = $7
+         WHERE team_name = $1`,
+		teamName,
+		string(policy.ReviewerStrategy),
+		policy.MinReviewers,
+		policy.MaxReviewers,
+		policy.AllowUnderstaffed,
+		policy.DefaultMaxOpenReviews,
+		policy.PairDiversityWindow,
+	)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	if err := replaceFallbacks(ctx, tx, teamName, policy.FallbackTeams); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TeamRepo) GetMergePolicy(ctx context.Context, teamName string) (domain.MergePolicy, error) {
+	var p domain.MergePolicy
+	var lead *string
+	err := r.db(ctx).QueryRow(ctx,
+		`SELECT required_approvals, team_lead_id, require_lead_approval
+         FROM teams
+         WHERE team_name = $1`,
+		teamName,
+	).Scan(&p.RequiredApprovals, &lead, &p.RequireLeadApproval)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.MergePolicy{}, domain.ErrNotFound
+		}
+		return domain.MergePolicy{}, err
+	}
+	if lead != nil {
+		p.TeamLeadID = *lead
+	}
+	return p, nil
+}
+
+func (r *TeamRepo) UpdateMergePolicy(ctx context.Context, teamName string, policy domain.MergePolicy) error {
+	var lead *string
+	if policy.TeamLeadID != "" {
+		lead = &policy.TeamLeadID
+	}
+
+	cmdTag, err := r.db(ctx).Exec(ctx,
+		`UPDATE teams
+         SET required_approvals    = $2,
+             team_lead_id          = $3,
+             require_lead_approval = $4
+         WHERE team_name = $1`,
+		teamName, policy.RequiredApprovals, lead, policy.RequireLeadApproval,
+	)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TeamRepo) GetReviewSLA(ctx context.Context, teamName string) (domain.ReviewSLA, error) {
+	var sla domain.ReviewSLA
+	var action, user *string
+	err := r.db(ctx).QueryRow(ctx,
+		`SELECT sla_response_hours, sla_escalation_hours, sla_escalation_action, sla_escalation_user_id
+         FROM teams
+         WHERE team_name = $1`,
+		teamName,
+	).Scan(&sla.ResponseHours, &sla.EscalationHours, &action, &user)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ReviewSLA{}, domain.ErrNotFound
+		}
+		return domain.ReviewSLA{}, err
+	}
+	if action != nil {
+		sla.EscalationAction = domain.EscalationAction(*action)
+	}
+	if user != nil {
+		sla.EscalationUserID = *user
+	}
+	return sla, nil
+}
+
+func (r *TeamRepo) UpdateReviewSLA(ctx context.Context, teamName string, sla domain.ReviewSLA) error {
+	var action, user *string
+	if sla.EscalationAction != "" {
+		a := string(sla.EscalationAction)
+		action = &a
+	}
+	if sla.EscalationUserID != "" {
+		user = &sla.EscalationUserID
+	}
+
+	cmdTag, err := r.db(ctx).Exec(ctx,
+		`UPDATE teams
+         SET sla_response_hours     = $2,
+             sla_escalation_hours   = $3,
+             sla_escalation_action  = $4,
+             sla_escalation_user_id = $5
+         WHERE team_name = $1`,
+		teamName, sla.ResponseHours, sla.EscalationHours, action, user,
+	)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// переписывает список запасных команд, порядок в списке = приоритет
+func replaceFallbacks(ctx context.Context, tx pgx.Tx, teamName string, fallbacks []string) error {
+	_, err := tx.Exec(ctx,
+		`DELETE FROM team_fallbacks WHERE team_name = $1`,
+		teamName,
+	)
+	if err != nil {
+		return err
+	}
+
+	for i, fb := range fallbacks {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO team_fallbacks (team_name, fallback_team, position)
+             VALUES ($1, $2, $3)`,
+			teamName, fb, i,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *TeamRepo) GetRoundRobinCursor(ctx context.Context, teamName string) (string, error) {
+	var cursor *string
+	err := r.db(ctx).QueryRow(ctx,
+		`SELECT round_robin_cursor FROM teams WHERE team_name = $1`,
+		teamName,
+	).Scan(&cursor)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrNotFound
+		}
+		return "", err
+	}
+	if cursor == nil {
+		return "", nil
+	}
+	return *cursor, nil
+}
+
+func (r *TeamRepo) SetRoundRobinCursor(ctx context.Context, teamName string, userID string) error {
+	_, err := r.db(ctx).Exec(ctx,
+		`UPDATE teams SET round_robin_cursor = $2 WHERE team_name = $1`,
+		teamName, userID,
+	)
+	return err
+}
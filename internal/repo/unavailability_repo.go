@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Unavailability interface {
+	Create(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error)
+
+	//периоды пользователя по дате начала
+	GetByUser(ctx context.Context, userID string) ([]domain.Unavailability, error)
+
+	//удаляет период и возвращает его, ErrNotFound если такого нет
+	Delete(ctx context.Context, id int64) (domain.Unavailability, error)
+}
+
+type UnavailabilityRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewUnavailabilityRepo(pool *pgxpool.Pool) *UnavailabilityRepo {
+	return &UnavailabilityRepo{
+		pool: pool,
+	}
+}
+
+func (r *UnavailabilityRepo) db(ctx context.Context) querier {
+	return executor(ctx, r.pool)
+}
+
+func (r *UnavailabilityRepo) Create(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error) {
+	err := r.db(ctx).QueryRow(ctx,
+		`INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+         VALUES ($1, $2, $3, $4)
+         RETURNING id`,
+		period.UserID, period.StartsAt, period.EndsAt, period.Reason,
+	).Scan(&period.ID)
+	if err != nil {
+		return domain.Unavailability{}, err
+	}
+	return period, nil
+}
+
+func (r *UnavailabilityRepo) GetByUser(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT id, user_id, starts_at, ends_at, reason
+         FROM user_unavailability
+         WHERE user_id = $1
+         ORDER BY starts_at, id`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := make([]domain.Unavailability, 0)
+	for rows.Next() {
+		var p domain.Unavailability
+		if err := rows.Scan(&p.ID, &p.UserID, &p.StartsAt, &p.EndsAt, &p.Reason); err != nil {
+			return nil, err
+		}
+		periods = append(periods, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+func (r *UnavailabilityRepo) Delete(ctx context.Context, id int64) (domain.Unavailability, error) {
+	var p domain.Unavailability
+	err := r.db(ctx).QueryRow(ctx,
+		`DELETE FROM user_unavailability
+         WHERE id = $1
+         RETURNING id, user_id, starts_at, ends_at, reason`,
+		id,
+	).Scan(&p.ID, &p.UserID, &p.StartsAt, &p.EndsAt, &p.Reason)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Unavailability{}, domain.ErrNotFound
+		}
+		return domain.Unavailability{}, err
+	}
+	return p, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"pr-reviewer-service/internal/domain"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -16,13 +17,44 @@ type User interface {
 	//получить user по id
 	GetByID(ctx context.Context, userID string) (domain.User, error)
 
-	//получить всех активных пользователей команды
-	GetActiveByTeam(ctx context.Context, teamName string) ([]domain.User, error)
+	//получить всех активных пользователей команды, кроме отсутствующих в момент at по user_unavailability
+	GetActiveByTeam(ctx context.Context, teamName string, at time.Time) ([]domain.User, error)
+
+	//пользователи по списку id одним запросом, ненайденные пропускаются
+	GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error)
+
+	//все участники команды, включая неактивных
+	GetByTeam(ctx context.Context, teamName string) ([]domain.User, error)
+
+	//те из userIDs, кто активен и не отсутствует в момент at
+	GetAvailableByIDs(ctx context.Context, userIDs []string, at time.Time) ([]domain.User, error)
+
+	//часовой пояс и рабочее время, start/end nil - без ограничения
+	SetWorkingHours(ctx context.Context, userID, timeZone string, start, end *string) error
 
 	//обновить флаг is_active /users/setIsActive
 	SetActive(ctx context.Context, userID string, isActive bool) error
+
+	//деактивирует перечисленных участников команды, возвращает найденных в команде
+	DeactivateTeamMembers(ctx context.Context, teamName string, userIDs []string) ([]string, error)
+
+	//заменить теги экспертизы пользователя /users/setTags
+	SetTags(ctx context.Context, userID string, tags []string) error
+
+	//лимит OPEN PR на ревью, nil - брать значение команды
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) error
 }
 
+// теги подтягиваются подзапросом, чтобы не ломать выборки по users
+const userColumns = `u.user_id, u.username, u.team_name, u.is_active, u.review_weight, u.max_open_reviews,
+                u.time_zone, u.work_start, u.work_end,
+                COALESCE((SELECT array_agg(t.tag ORDER BY t.tag) FROM user_tags t WHERE t.user_id = u.user_id), '{}')`
+
+// активен и ни один период отсутствия не покрывает момент $2
+const availableCondition = `u.is_active = true
+           AND NOT EXISTS (SELECT 1 FROM user_unavailability ua
+                           WHERE ua.user_id = u.user_id AND ua.starts_at <= $2 AND ua.ends_at > $2)`
+
 type UserRepo struct {
 	pool *pgxpool.Pool
 }
@@ -33,6 +65,10 @@ func NewUserRepo(pool *pgxpool.Pool) *UserRepo {
 	}
 }
 
+func (r *UserRepo) db(ctx context.Context) querier {
+	return executor(ctx, r.pool)
+}
+
 func (r *UserRepo) UpsertTeamMembers(ctx context.Context, teamName string, members []domain.TeamMember) error {
 	if len(members) == 0 {
 		return nil
@@ -41,18 +77,20 @@ func (r *UserRepo) UpsertTeamMembers(ctx context.Context, teamName string, membe
 	batch := &pgx.Batch{}
 	for _, m := range members {
 		batch.Queue(
-			`INSERT INTO users (user_id, username, team_name, is_active)
-             VALUES ($1, $2, $3, $4)
+			`INSERT INTO users (user_id, username, team_name, is_active, review_weight, max_open_reviews)
+             VALUES ($1, $2, $3, $4, COALESCE($5, 1), $6)
              ON CONFLICT (user_id)
              DO UPDATE SET
                  username = EXCLUDED.username,
                  team_name = EXCLUDED.team_name,
-                 is_active = EXCLUDED.is_active`,
-			m.UserID, m.Username, teamName, m.IsActive,
+                 is_active = EXCLUDED.is_active,
+                 review_weight = COALESCE($5, users.review_weight),
+                 max_open_reviews = COALESCE($6, users.max_open_reviews)`,
+			m.UserID, m.Username, teamName, m.IsActive, m.ReviewWeight, m.MaxOpenReviews,
 		)
 	}
 
-	br := r.pool.SendBatch(ctx, batch)
+	br := r.db(ctx).SendBatch(ctx, batch)
 	defer br.Close()
 
 	for range members {
@@ -65,12 +103,13 @@ func (r *UserRepo) UpsertTeamMembers(ctx context.Context, teamName string, membe
 
 func (r *UserRepo) GetByID(ctx context.Context, userID string) (domain.User, error) {
 	var u domain.User
-	err := r.pool.QueryRow(ctx,
-		`SELECT user_id, username, team_name, is_active
-         FROM users
-         WHERE user_id = $1`,
+	err := r.db(ctx).QueryRow(ctx,
+		`SELECT `+userColumns+`
+         FROM users u
+         WHERE u.user_id = $1`,
 		userID,
-	).Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive)
+	).Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.ReviewWeight, &u.MaxOpenReviews,
+		&u.TimeZone, &u.WorkStart, &u.WorkEnd, &u.Tags)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.User{}, domain.ErrNotFound
@@ -80,22 +119,66 @@ func (r *UserRepo) GetByID(ctx context.Context, userID string) (domain.User, err
 	return u, nil
 }
 
-func (r *UserRepo) GetActiveByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
-	rows, err := r.pool.Query(ctx,
-		`SELECT user_id, username, team_name, is_active
-         FROM users
-         WHERE team_name = $1 AND is_active = true`,
+func (r *UserRepo) GetActiveByTeam(ctx context.Context, teamName string, at time.Time) ([]domain.User, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT `+userColumns+`
+         FROM users u
+         WHERE u.team_name = $1 AND `+availableCondition,
+		teamName, at,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanUsers(rows)
+}
+
+func (r *UserRepo) GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT `+userColumns+`
+         FROM users u
+         WHERE u.user_id = ANY($1)`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanUsers(rows)
+}
+
+func (r *UserRepo) GetByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT `+userColumns+`
+         FROM users u
+         WHERE u.team_name = $1`,
 		teamName,
 	)
 	if err != nil {
 		return nil, err
 	}
+	return scanUsers(rows)
+}
+
+func (r *UserRepo) GetAvailableByIDs(ctx context.Context, userIDs []string, at time.Time) ([]domain.User, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`SELECT `+userColumns+`
+         FROM users u
+         WHERE u.user_id = ANY($1) AND `+availableCondition,
+		userIDs, at,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanUsers(rows)
+}
+
+func scanUsers(rows pgx.Rows) ([]domain.User, error) {
 	defer rows.Close()
 
 	users := make([]domain.User, 0)
 	for rows.Next() {
 		var u domain.User
-		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.ReviewWeight, &u.MaxOpenReviews,
+			&u.TimeZone, &u.WorkStart, &u.WorkEnd, &u.Tags); err != nil {
 			return nil, err
 		}
 		users = append(users, u)
@@ -107,7 +190,7 @@ func (r *UserRepo) GetActiveByTeam(ctx context.Context, teamName string) ([]doma
 }
 
 func (r *UserRepo) SetActive(ctx context.Context, userID string, isActive bool) error {
-	cmdTag, err := r.pool.Exec(ctx,
+	cmdTag, err := r.db(ctx).Exec(ctx,
 		`UPDATE users SET is_active = $2 WHERE user_id = $1`,
 		userID, isActive,
 	)
@@ -119,3 +202,97 @@ func (r *UserRepo) SetActive(ctx context.Context, userID string, isActive bool)
 	}
 	return nil
 }
+
+func (r *UserRepo) SetTags(ctx context.Context, userID string, tags []string) error {
+	tx, err := r.db(ctx).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM users WHERE user_id = $1)`,
+		userID,
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrNotFound
+	}
+
+	_, err = tx.Exec(ctx,
+		`DELETE FROM user_tags WHERE user_id = $1`,
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO user_tags (user_id, tag)
+         SELECT $1, unnest($2::text[])`,
+		userID, tags,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *UserRepo) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) error {
+	cmdTag, err := r.db(ctx).Exec(ctx,
+		`UPDATE users SET max_open_reviews = $2 WHERE user_id = $1`,
+		userID, limit,
+	)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepo) SetWorkingHours(ctx context.Context, userID, timeZone string, start, end *string) error {
+	cmdTag, err := r.db(ctx).Exec(ctx,
+		`UPDATE users SET time_zone = $2, work_start = $3, work_end = $4 WHERE user_id = $1`,
+		userID, timeZone, start, end,
+	)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepo) DeactivateTeamMembers(ctx context.Context, teamName string, userIDs []string) ([]string, error) {
+	rows, err := r.db(ctx).Query(ctx,
+		`UPDATE users
+         SET is_active = false
+         WHERE team_name = $1 AND user_id = ANY($2)
+         RETURNING user_id`,
+		teamName, userIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	updated := make([]string, 0, len(userIDs))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		updated = append(updated, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
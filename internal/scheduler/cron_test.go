@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func utc(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestScheduleNext(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		from string
+		want string
+	}{
+		{"every minute", "* * * * *", "2026-10-17 10:07", "2026-10-17 10:08"},
+		{"strictly after from", "7 10 * * *", "2026-10-17 10:07", "2026-10-18 10:07"},
+		{"list", "5,20,40 * * * *", "2026-10-17 10:21", "2026-10-17 10:40"},
+		{"range", "0 9-17 * * *", "2026-10-17 17:30", "2026-10-18 09:00"},
+		{"star step", "*/15 * * * *", "2026-10-17 10:07", "2026-10-17 10:15"},
+		{"start step", "5/15 * * * *", "2026-10-17 10:21", "2026-10-17 10:35"},
+		{"start step wraps hour", "5/15 * * * *", "2026-10-17 10:50", "2026-10-17 11:05"},
+		{"range step", "0 0 * * 1-5/2", "2026-10-17 00:00", "2026-10-19 00:00"}, // суббота -> пн
+		{"range step skips", "0 0 * * 1-5/2", "2026-10-19 00:00", "2026-10-21 00:00"},
+		{"dow 7 is sunday", "0 12 * * 7", "2026-10-17 10:00", "2026-10-18 12:00"},
+		{"dow 0 is sunday", "0 12 * * 0", "2026-10-17 10:00", "2026-10-18 12:00"},
+		{"weekdays skip weekend", "0 9 * * 1-5", "2026-10-16 10:00", "2026-10-19 09:00"},
+		// оба поля дня заданы: достаточно совпадения любого
+		{"dom or dow, dom first", "0 0 20 * 1", "2026-10-17 00:00", "2026-10-19 00:00"},
+		{"dom or dow, dow first", "0 0 18 * 5", "2026-10-17 00:00", "2026-10-18 00:00"},
+		{"star dom uses dow only", "0 0 * * 5", "2026-10-17 00:00", "2026-10-23 00:00"},
+		{"star step dom is any", "0 0 */1 * 5", "2026-10-17 00:00", "2026-10-23 00:00"},
+		{"month rollover", "30 2 1 * *", "2026-10-17 10:00", "2026-11-01 02:30"},
+		{"short month skipped", "0 0 31 * *", "2026-11-01 00:00", "2026-12-31 00:00"},
+		{"year rollover", "0 0 1 1 *", "2026-10-17 10:00", "2027-01-01 00:00"},
+		{"last minute of year", "59 23 31 12 *", "2026-12-31 23:58", "2026-12-31 23:59"},
+		{"leap day", "0 0 29 2 *", "2026-10-17 00:00", "2028-02-29 00:00"},
+		{"hourly", "@hourly", "2026-10-17 10:07", "2026-10-17 11:00"},
+		{"daily", "@daily", "2026-10-17 10:07", "2026-10-18 00:00"},
+		{"weekly", "@weekly", "2026-10-17 10:07", "2026-10-18 00:00"},
+		{"every aligned to interval", "@every 90m", "2026-10-17 10:07", "2026-10-17 10:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchedule(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q): %v", tt.expr, err)
+			}
+			if got := s.Next(utc(tt.from)); !got.Equal(utc(tt.want)) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got.Format("2006-01-02 15:04"), tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleNextNeverFires(t *testing.T) {
+	s, err := ParseSchedule("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("ParseSchedule: %v", err)
+	}
+	if got := s.Next(utc("2026-10-17 10:00")); !got.IsZero() {
+		t.Errorf("Next = %s, want zero time", got)
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1-x * * * *",
+		"@every 1ms",
+		"@every soon",
+		"@yearly",
+	} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Errorf("ParseSchedule(%q): expected error", expr)
+		}
+	}
+}
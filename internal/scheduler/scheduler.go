@@ -0,0 +1,307 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Locker не дает двум репликам выполнять одну задачу одновременно или повторять один запуск
+type Locker interface {
+	// TryLock берет лок без ожидания; ok = false, если его держит другая реплика
+	TryLock(ctx context.Context, key string) (unlock func(), ok bool, err error)
+
+	// Claim отмечает запуск задачи по расписанию в slot, вызывается под локом задачи.
+	// ok = false, если этот или более поздний запуск уже забрала другая реплика
+	Claim(ctx context.Context, job string, slot time.Time) (ok bool, err error)
+}
+
+// Clock — источник времени и таймеров планировщика, в тестах подменяется
+type Clock interface {
+	Now() time.Time
+	// After как time.After
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now().UTC() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// LockKey — ключ лока задачи job, его же берет ручной запуск той же работы вне планировщика
+func LockKey(job string) string {
+	return "job:" + job
+}
+
+// Job — периодическая задача
+type Job struct {
+	Name     string
+	Schedule string // cron выражение, см. ParseSchedule
+	// случайная задержка [0, Jitter) перед каждым запуском, чтобы реплики не стартовали одновременно
+	Jitter time.Duration
+	// 0 - без ограничения
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+// JobStatus — состояние задачи в этом процессе
+type JobStatus struct {
+	Name          string     `json:"name"`
+	Schedule      string     `json:"schedule"`
+	Running       bool       `json:"running"`
+	NextRunAt     *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt     *time.Time `json:"last_run_at,omitempty"`
+	LastDuration  string     `json:"last_duration,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastSkippedAt *time.Time `json:"last_skipped_at,omitempty"` // лок был у другой реплики
+	Runs          int        `json:"runs"`
+	Failures      int        `json:"failures"`
+}
+
+type job struct {
+	Job
+	schedule Schedule
+	status   JobStatus
+}
+
+type Scheduler struct {
+	locker Locker
+	clock  Clock
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	jobs    map[string]*job
+	started bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	cancel   context.CancelFunc // отменяет выполняющиеся задачи
+	running  sync.WaitGroup
+}
+
+func New(locker Locker, clock Clock, logger *slog.Logger) *Scheduler {
+	return &Scheduler{
+		locker: locker,
+		clock:  clock,
+		logger: logger,
+		jobs:   make(map[string]*job),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Register добавляет задачу, до Start
+func (s *Scheduler) Register(j Job) error {
+	if j.Name == "" || j.Run == nil {
+		return errors.New("scheduler: job name and run are required")
+	}
+	if j.Jitter < 0 || j.Timeout < 0 {
+		return fmt.Errorf("scheduler: job %q: jitter and timeout must not be negative", j.Name)
+	}
+	sched, err := ParseSchedule(j.Schedule)
+	if err != nil {
+		return fmt.Errorf("scheduler: job %q: %w", j.Name, err)
+	}
+	// например 0 0 30 2 *: такое расписание разбирается, но никогда не срабатывает
+	if sched.Next(s.clock.Now().UTC()).IsZero() {
+		return fmt.Errorf("scheduler: job %q: schedule %q never fires", j.Name, j.Schedule)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("scheduler: job %q registered after start", j.Name)
+	}
+	if _, ok := s.jobs[j.Name]; ok {
+		return fmt.Errorf("scheduler: job %q already registered", j.Name)
+	}
+	s.jobs[j.Name] = &job{
+		Job:      j,
+		schedule: sched,
+		status:   JobStatus{Name: j.Name, Schedule: j.Schedule},
+	}
+	return nil
+}
+
+// Start запускает по горутине на задачу
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, j := range s.jobs {
+		s.running.Add(1)
+		go s.loop(ctx, j)
+	}
+}
+
+// Stop перестает запускать задачи и ждет выполняющиеся. Когда ctx истекает,
+// задачам отменяется контекст и Stop возвращает ошибку ctx
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return nil
+	}
+	s.mu.Unlock()
+
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// Jobs возвращает состояние задач, отсортированное по имени
+func (s *Scheduler) Jobs() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		res = append(res, j.status)
+	}
+	sort.Slice(res, func(a, b int) bool { return res[a].Name < res[b].Name })
+	return res
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	defer s.running.Done()
+
+	for {
+		slot, next := s.scheduleNext(j)
+		if slot.IsZero() {
+			s.logger.Error("job has no next run, stopping", slog.String("job", j.Name))
+			return
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-s.clock.After(next.Sub(s.clock.Now())):
+		}
+
+		s.run(ctx, j, slot)
+	}
+}
+
+// slot — время запуска по расписанию, одинаковое у всех реплик; next — он же с учетом jitter,
+// сохраняется в NextRunAt
+func (s *Scheduler) scheduleNext(j *job) (slot, next time.Time) {
+	slot = j.schedule.Next(s.clock.Now().UTC())
+	if slot.IsZero() {
+		return slot, slot
+	}
+	next = slot
+	if j.Jitter > 0 {
+		next = next.Add(rand.N(j.Jitter))
+	}
+
+	s.mu.Lock()
+	j.status.NextRunAt = &next
+	s.mu.Unlock()
+	return slot, next
+}
+
+// выполняет запуск slot, если его не выполняет и не выполнила другая реплика
+func (s *Scheduler) run(ctx context.Context, j *job, slot time.Time) {
+	unlock, ok, err := s.locker.TryLock(ctx, LockKey(j.Name))
+	if err != nil {
+		s.logger.Error("failed to lock job", slog.String("job", j.Name), slog.Any("error", err))
+		return
+	}
+	if !ok {
+		s.skipped(j, "job is running on another replica")
+		return
+	}
+	defer unlock()
+
+	// лок держится только пока задача выполняется: реплика с большим jitter
+	// получит его после первой, поэтому запуск забирается по slot
+	claimed, err := s.locker.Claim(ctx, j.Name, slot)
+	if err != nil {
+		s.logger.Error("failed to claim job run", slog.String("job", j.Name), slog.Any("error", err))
+		return
+	}
+	if !claimed {
+		s.skipped(j, "job run already done by another replica")
+		return
+	}
+
+	runCtx := ctx
+	if j.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, j.Timeout)
+		defer cancel()
+	}
+
+	started := s.clock.Now().UTC()
+	s.mu.Lock()
+	j.status.Running = true
+	j.status.LastRunAt = &started
+	s.mu.Unlock()
+
+	err = s.safeRun(runCtx, j)
+	duration := s.clock.Now().UTC().Sub(started)
+
+	s.mu.Lock()
+	j.status.Running = false
+	j.status.Runs++
+	j.status.LastDuration = duration.String()
+	j.status.LastError = ""
+	if err != nil {
+		j.status.Failures++
+		j.status.LastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		s.logger.Error("job failed", slog.String("job", j.Name), slog.Duration("duration", duration), slog.Any("error", err))
+		return
+	}
+	s.logger.Debug("job finished", slog.String("job", j.Name), slog.Duration("duration", duration))
+}
+
+func (s *Scheduler) skipped(j *job, msg string) {
+	now := s.clock.Now().UTC()
+	s.mu.Lock()
+	j.status.LastSkippedAt = &now
+	s.mu.Unlock()
+	s.logger.Debug(msg, slog.String("job", j.Name))
+}
+
+// паника в задаче не должна ронять сервис
+func (s *Scheduler) safeRun(ctx context.Context, j *job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return j.Run(ctx)
+}
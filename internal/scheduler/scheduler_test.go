@@ -0,0 +1,363 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// часы, которые двигает только тест; таймеры срабатывают в Advance
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+	armed  chan struct{} // по сигналу на каждый вызов After
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, armed: make(chan struct{}, 64)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+	} else {
+		c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	}
+	c.mu.Unlock()
+
+	c.armed <- struct{}{}
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.ch <- c.now
+	}
+	c.timers = pending
+}
+
+// ждет n вызовов After: значит, столько циклов дошли до ожидания следующего запуска
+func (c *fakeClock) waitArmed(t *testing.T, n int) {
+	t.Helper()
+	for range n {
+		select {
+		case <-c.armed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("scheduler did not arm a timer")
+		}
+	}
+}
+
+// лок и отметки запусков общие для всех «реплик», которые им пользуются
+type fakeLocker struct {
+	mu      sync.Mutex
+	busy    bool // лок держит кто-то еще
+	held    map[string]bool
+	claimed map[string]time.Time
+}
+
+func newFakeLocker() *fakeLocker {
+	return &fakeLocker{held: make(map[string]bool), claimed: make(map[string]time.Time)}
+}
+
+func (l *fakeLocker) TryLock(_ context.Context, key string) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.busy || l.held[key] {
+		return nil, false, nil
+	}
+	l.held[key] = true
+	return func() {
+		l.mu.Lock()
+		delete(l.held, key)
+		l.mu.Unlock()
+	}, true, nil
+}
+
+func (l *fakeLocker) Claim(_ context.Context, job string, slot time.Time) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if last, ok := l.claimed[job]; ok && !last.Before(slot) {
+		return false, nil
+	}
+	l.claimed[job] = slot
+	return true, nil
+}
+
+func newTestScheduler() *Scheduler {
+	s, _, _ := newFakeScheduler()
+	return s
+}
+
+func newFakeScheduler() (*Scheduler, *fakeClock, *fakeLocker) {
+	clock := newFakeClock(utc("2026-10-17 10:07"))
+	locker := newFakeLocker()
+	return newReplica(locker, clock), clock, locker
+}
+
+func newReplica(locker Locker, clock Clock) *Scheduler {
+	return New(locker, clock, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func registered(t *testing.T, s *Scheduler, j Job) *job {
+	t.Helper()
+	if err := s.Register(j); err != nil {
+		t.Fatal(err)
+	}
+	return s.jobs[j.Name]
+}
+
+func noop(context.Context) error { return nil }
+
+func TestRegisterRejectsInvalidJobs(t *testing.T) {
+	tests := []struct {
+		name    string
+		job     Job
+		wantErr string
+	}{
+		{"never fires", Job{Name: "j", Schedule: "0 0 30 2 *", Run: noop}, "never fires"},
+		{"bad schedule", Job{Name: "j", Schedule: "* * *", Run: noop}, "expected 5 fields"},
+		{"no run", Job{Name: "j", Schedule: "* * * * *"}, "required"},
+		{"negative timeout", Job{Name: "j", Schedule: "* * * * *", Run: noop, Timeout: -1}, "negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newTestScheduler().Register(tt.job)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Register err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	s := newTestScheduler()
+	if err := s.Register(Job{Name: "j", Schedule: "@hourly", Run: noop}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register(Job{Name: "j", Schedule: "@daily", Run: noop}); err == nil {
+		t.Error("expected error for duplicate job")
+	}
+}
+
+func TestScheduleNextUsesClock(t *testing.T) {
+	s, clock, _ := newFakeScheduler()
+	j := registered(t, s, Job{Name: "j", Schedule: "*/10 * * * *", Run: noop})
+
+	if slot, next := s.scheduleNext(j); !slot.Equal(utc("2026-10-17 10:10")) || !next.Equal(slot) {
+		t.Errorf("slot = %s, next = %s, want 10:10", slot, next)
+	}
+	clock.Advance(5 * time.Minute)
+	if slot, _ := s.scheduleNext(j); !slot.Equal(utc("2026-10-17 10:20")) {
+		t.Errorf("slot = %s, want 10:20", slot)
+	}
+	if st := s.Jobs()[0]; st.NextRunAt == nil || !st.NextRunAt.Equal(utc("2026-10-17 10:20")) {
+		t.Errorf("NextRunAt = %v", st.NextRunAt)
+	}
+}
+
+func TestScheduleNextJitter(t *testing.T) {
+	s, _, _ := newFakeScheduler()
+	j := registered(t, s, Job{Name: "j", Schedule: "*/10 * * * *", Jitter: 30 * time.Second, Run: noop})
+
+	base := utc("2026-10-17 10:10")
+	for range 20 {
+		slot, next := s.scheduleNext(j)
+		if !slot.Equal(base) {
+			t.Fatalf("slot = %s, jitter must not move it", slot)
+		}
+		if next.Before(base) || !next.Before(base.Add(30*time.Second)) {
+			t.Fatalf("next = %s, want in [%s, +30s)", next, base)
+		}
+	}
+}
+
+func TestRunRecordsStatus(t *testing.T) {
+	s, clock, _ := newFakeScheduler()
+	fail := false
+	j := registered(t, s, Job{Name: "j", Schedule: "@hourly", Run: func(context.Context) error {
+		clock.Advance(1500 * time.Millisecond)
+		if fail {
+			return errors.New("boom")
+		}
+		return nil
+	}})
+
+	started := clock.Now()
+	s.run(context.Background(), j, utc("2026-10-17 11:00"))
+	st := s.Jobs()[0]
+	if st.Running || st.Runs != 1 || st.Failures != 0 || st.LastError != "" {
+		t.Errorf("status after success = %+v", st)
+	}
+	if st.LastRunAt == nil || !st.LastRunAt.Equal(started) || st.LastDuration != "1.5s" {
+		t.Errorf("last run = %v, duration %q", st.LastRunAt, st.LastDuration)
+	}
+
+	fail = true
+	s.run(context.Background(), j, utc("2026-10-17 12:00"))
+	st = s.Jobs()[0]
+	if st.Runs != 2 || st.Failures != 1 || st.LastError != "boom" {
+		t.Errorf("status after failure = %+v", st)
+	}
+
+	fail = false
+	s.run(context.Background(), j, utc("2026-10-17 13:00"))
+	if st = s.Jobs()[0]; st.LastError != "" || st.Failures != 1 {
+		t.Errorf("error not cleared after success: %+v", st)
+	}
+}
+
+func TestRunSkipsWhenLockedElsewhere(t *testing.T) {
+	s, clock, locker := newFakeScheduler()
+	ran := false
+	j := registered(t, s, Job{Name: "j", Schedule: "@hourly", Run: func(context.Context) error {
+		ran = true
+		return nil
+	}})
+
+	locker.busy = true
+	s.run(context.Background(), j, utc("2026-10-17 11:00"))
+	st := s.Jobs()[0]
+	if ran || st.Runs != 0 {
+		t.Errorf("job ran while locked elsewhere: %+v", st)
+	}
+	if st.LastSkippedAt == nil || !st.LastSkippedAt.Equal(clock.Now()) {
+		t.Errorf("LastSkippedAt = %v", st.LastSkippedAt)
+	}
+}
+
+func TestRunSkipsClaimedSlot(t *testing.T) {
+	locker := newFakeLocker()
+	clock := newFakeClock(utc("2026-10-17 10:07"))
+	runs := 0
+	job := Job{Name: "j", Schedule: "@hourly", Run: func(context.Context) error {
+		runs++
+		return nil
+	}}
+	first, second := newReplica(locker, clock), newReplica(locker, clock)
+	j1, j2 := registered(t, first, job), registered(t, second, job)
+
+	// вторая реплика проснулась позже из-за jitter, лок уже свободен
+	first.run(context.Background(), j1, utc("2026-10-17 11:00"))
+	second.run(context.Background(), j2, utc("2026-10-17 11:00"))
+	if runs != 1 {
+		t.Fatalf("slot ran %d times, want once", runs)
+	}
+	if st := second.Jobs()[0]; st.Runs != 0 || st.LastSkippedAt == nil {
+		t.Errorf("second replica status = %+v", st)
+	}
+
+	// отставшая реплика не повторяет и более ранний запуск
+	second.run(context.Background(), j2, utc("2026-10-17 10:00"))
+	if runs != 1 {
+		t.Fatalf("earlier slot ran after a later one")
+	}
+
+	second.run(context.Background(), j2, utc("2026-10-17 12:00"))
+	if runs != 2 {
+		t.Errorf("next slot did not run: runs = %d", runs)
+	}
+}
+
+func TestReplicasRunEachSlotOnce(t *testing.T) {
+	locker := newFakeLocker()
+	clock := newFakeClock(utc("2026-10-17 10:07"))
+	var runs atomic.Int32
+	job := Job{Name: "j", Schedule: "*/10 * * * *", Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}}
+
+	replicas := []*Scheduler{newReplica(locker, clock), newReplica(locker, clock)}
+	for _, s := range replicas {
+		if err := s.Register(job); err != nil {
+			t.Fatal(err)
+		}
+		s.Start()
+	}
+	t.Cleanup(func() {
+		for _, s := range replicas {
+			_ = s.Stop(context.Background())
+		}
+	})
+
+	clock.waitArmed(t, 2)
+	clock.Advance(2 * time.Minute) // 10:09, запуск в 10:10 еще не наступил
+	if n := runs.Load(); n != 0 {
+		t.Fatalf("job ran %d times before its slot", n)
+	}
+
+	for i, at := range []string{"2026-10-17 10:10", "2026-10-17 10:20"} {
+		clock.Advance(utc(at).Sub(clock.Now()))
+		clock.waitArmed(t, 2) // обе реплики обработали запуск и ждут следующий
+		if n := runs.Load(); n != int32(i+1) {
+			t.Fatalf("after %s job ran %d times, want %d", at, n, i+1)
+		}
+	}
+}
+
+func TestStopInterruptsWaiting(t *testing.T) {
+	s, clock, _ := newFakeScheduler()
+	registered(t, s, Job{Name: "j", Schedule: "@daily", Run: noop})
+	s.Start()
+	clock.waitArmed(t, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Stop(ctx); err != nil {
+		t.Fatalf("Stop = %v", err)
+	}
+}
+
+func TestRunTimeoutAndPanic(t *testing.T) {
+	s, _, _ := newFakeScheduler()
+	var hasDeadline bool
+	timed := registered(t, s, Job{Name: "timed", Schedule: "@hourly", Timeout: time.Minute, Run: func(ctx context.Context) error {
+		_, hasDeadline = ctx.Deadline()
+		return nil
+	}})
+	panics := registered(t, s, Job{Name: "panics", Schedule: "@hourly", Run: func(context.Context) error {
+		panic("oops")
+	}})
+
+	s.run(context.Background(), timed, utc("2026-10-17 11:00"))
+	if !hasDeadline {
+		t.Error("job with timeout got context without deadline")
+	}
+
+	s.run(context.Background(), panics, utc("2026-10-17 11:00"))
+	st := s.Jobs()[0] // по имени: panics < timed
+	if st.Name != "panics" || st.Failures != 1 || st.LastError != "panic: oops" {
+		t.Errorf("status after panic = %+v", st)
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import "pr-reviewer-service/internal/domain"
+
+// собирает объяснение назначения: кого рассматривали и почему выбрали или пропустили.
+// Причина пропуска фиксируется при первом появлении кандидата, выбор ее перезаписывает
+type assignmentTrace struct {
+	candidates []domain.CandidateExplanation
+	index      map[string]int
+}
+
+func newAssignmentTrace() *assignmentTrace {
+	return &assignmentTrace{
+		candidates: make([]domain.CandidateExplanation, 0),
+		index:      make(map[string]int),
+	}
+}
+
+func (t *assignmentTrace) skip(userID, team string, reason domain.CandidateReason, detail string) {
+	if _, ok := t.index[userID]; ok {
+		return
+	}
+	t.index[userID] = len(t.candidates)
+	t.candidates = append(t.candidates, domain.CandidateExplanation{
+		UserID:   userID,
+		TeamName: team,
+		Reason:   reason,
+		Detail:   detail,
+	})
+}
+
+func (t *assignmentTrace) pick(userID, team string, reason domain.CandidateReason, detail string) {
+	c := domain.CandidateExplanation{
+		UserID:   userID,
+		TeamName: team,
+		Picked:   true,
+		Reason:   reason,
+		Detail:   detail,
+	}
+	if i, ok := t.index[userID]; ok {
+		t.candidates[i] = c
+		return
+	}
+	t.index[userID] = len(t.candidates)
+	t.candidates = append(t.candidates, c)
+}
+
+// снимает выбор с ранее выбранного кандидата
+func (t *assignmentTrace) unpick(userID string, reason domain.CandidateReason, detail string) {
+	if i, ok := t.index[userID]; ok {
+		t.candidates[i].Picked = false
+		t.candidates[i].Reason = reason
+		t.candidates[i].Detail = detail
+	}
+}
@@ -0,0 +1,25 @@
+package service
+
+import "time"
+
+// Clock — источник текущего времени для сервисов, в тестах подменяется фиксированным
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc позволяет передать функцию как Clock
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now().UTC()
+}
+
+func SystemClock() Clock {
+	return systemClock{}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"pr-reviewer-service/internal/domain"
+	"regexp"
+	"strings"
+)
+
+// ParseCodeowners разбирает файл в формате CODEOWNERS:
+//
+//	# комментарий
+//	*.go            @org/backend
+//	/docs/          @u1 @org/docs
+//
+// Владелец вида @org/team — команда team, @user_id — конкретный пользователь.
+func ParseCodeowners(content string) ([]domain.OwnershipRule, error) {
+	rules := make([]domain.OwnershipRule, 0)
+
+	sc := bufio.NewScanner(strings.NewReader(content))
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+
+		line := sc.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule := domain.OwnershipRule{
+			Pattern: fields[0],
+			Teams:   make([]string, 0),
+			Users:   make([]string, 0),
+		}
+		if _, err := compilePattern(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("%w: line %d: bad pattern %q", domain.ErrInvalidCodeowners, lineNo, rule.Pattern)
+		}
+
+		for _, owner := range fields[1:] {
+			name, ok := strings.CutPrefix(owner, "@")
+			if !ok || name == "" {
+				return nil, fmt.Errorf("%w: line %d: owner %q must start with @", domain.ErrInvalidCodeowners, lineNo, owner)
+			}
+
+			if i := strings.LastIndex(name, "/"); i >= 0 {
+				team := name[i+1:]
+				if team == "" {
+					return nil, fmt.Errorf("%w: line %d: empty team in %q", domain.ErrInvalidCodeowners, lineNo, owner)
+				}
+				rule.Teams = append(rule.Teams, team)
+				continue
+			}
+			rule.Users = append(rule.Users, name)
+		}
+
+		// строка без владельцев снимает владение с путей, как в GitHub
+		rules = append(rules, rule)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidCodeowners, err)
+	}
+
+	return rules, nil
+}
+
+// владельцы измененных файлов: для каждого файла берется последнее совпавшее правило.
+// Порядок — по первому упоминанию
+func resolveOwners(rules []domain.OwnershipRule, files []string) (teams, users []string) {
+	compiled := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		// правила валидируются при загрузке, битый шаблон просто не совпадет
+		compiled[i], _ = compilePattern(rule.Pattern)
+	}
+
+	seenTeams := make(map[string]struct{})
+	seenUsers := make(map[string]struct{})
+	for _, file := range files {
+		file = strings.TrimPrefix(file, "/")
+
+		var match *domain.OwnershipRule
+		for i := range rules {
+			if compiled[i] != nil && compiled[i].MatchString(file) {
+				match = &rules[i]
+			}
+		}
+		if match == nil {
+			continue
+		}
+
+		for _, t := range match.Teams {
+			if _, ok := seenTeams[t]; !ok {
+				seenTeams[t] = struct{}{}
+				teams = append(teams, t)
+			}
+		}
+		for _, u := range match.Users {
+			if _, ok := seenUsers[u]; !ok {
+				seenUsers[u] = struct{}{}
+				users = append(users, u)
+			}
+		}
+	}
+
+	return teams, users
+}
+
+// переводит шаблон в стиле gitignore в регулярку:
+// ведущий "/" или "/" внутри шаблона привязывают его к корню, иначе он совпадает на любой глубине;
+// завершающий "/" означает каталог; "**" — любое число каталогов.
+// Шаблон с буквальным последним сегментом (без * и ?) может совпасть с каталогом
+// и тогда покрывает все файлы внутри него; шаблон с маской в последнем сегменте
+// совпадает только с самим путем, поэтому docs/* не берет docs/a/b.md
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	p := pattern
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(p[i])))
+		}
+	}
+
+	last := p[strings.LastIndex(p, "/")+1:]
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.ContainsAny(last, "*?"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-service/internal/domain"
+	"slices"
+	"testing"
+)
+
+func TestCompilePattern(t *testing.T) {
+	// примеры из документации GitHub по CODEOWNERS
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.js", "app.js", true},
+		{"*.js", "src/web/app.js", true},
+		{"*.js", "app.jsx", false},
+		{"*.go", "x.go/readme.md", false},
+
+		{"docs/*", "docs/getting-started.md", true},
+		{"docs/*", "docs/build-app/troubleshooting.md", false},
+		{"docs/*", "src/docs/readme.md", false},
+
+		{"/build/logs/", "build/logs/app.log", true},
+		{"/build/logs/", "build/logs/2025/app.log", true},
+		{"/build/logs/", "src/build/logs/app.log", false},
+		{"/build/logs/", "build/logs", false},
+
+		{"**/logs", "logs/app.log", true},
+		{"**/logs", "build/logs/app.log", true},
+		{"**/logs", "deeply/nested/logs/2025/app.log", true},
+		{"**/logs", "build/logsx/app.log", false},
+
+		{"apps/", "apps/web/index.ts", true},
+		{"apps/", "src/apps/web/index.ts", true},
+		{"/scripts/", "scripts/deploy.sh", true},
+		{"/scripts/", "tools/scripts/deploy.sh", false},
+		{"apps/github", "apps/github/main.go", true},
+		{"apps/github", "src/apps/github/main.go", false},
+		{"docs", "src/docs/a/b.md", true},
+
+		{"src/?.go", "src/a.go", true},
+		{"src/?.go", "src/ab.go", false},
+		{"/docs/**", "docs/a/b/c.md", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			re, err := compilePattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("compilePattern(%q): %v", tt.pattern, err)
+			}
+			if got := re.MatchString(tt.path); got != tt.want {
+				t.Errorf("%q matches %q = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCodeowners(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []domain.OwnershipRule
+		wantErr bool
+	}{
+		{
+			name: "teams users and comments",
+			content: `# комментарий
+*.js    @org/frontend @u1  # хвостовой комментарий
+
+/build/logs/ @u2
+docs/*  @org/docs`,
+			want: []domain.OwnershipRule{
+				{Pattern: "*.js", Teams: []string{"frontend"}, Users: []string{"u1"}},
+				{Pattern: "/build/logs/", Teams: []string{}, Users: []string{"u2"}},
+				{Pattern: "docs/*", Teams: []string{"docs"}, Users: []string{}},
+			},
+		},
+		{
+			name:    "rule without owners",
+			content: "/apps/github",
+			want: []domain.OwnershipRule{
+				{Pattern: "/apps/github", Teams: []string{}, Users: []string{}},
+			},
+		},
+		{
+			name:    "owner without @",
+			content: "*.js frontend",
+			wantErr: true,
+		},
+		{
+			name:    "empty team",
+			content: "*.js @org/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCodeowners(tt.content)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidCodeowners) {
+					t.Fatalf("err = %v, want ErrInvalidCodeowners", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d rules, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i].Pattern != tt.want[i].Pattern ||
+					!slices.Equal(got[i].Teams, tt.want[i].Teams) ||
+					!slices.Equal(got[i].Users, tt.want[i].Users) {
+					t.Errorf("rule %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResolveOwners(t *testing.T) {
+	rules, err := ParseCodeowners(`
+*            @org/global
+*.js         @org/frontend
+docs/*       @org/docs
+/build/logs/ @u1
+**/logs      @u2
+/apps/github
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		files     []string
+		wantTeams []string
+		wantUsers []string
+	}{
+		{
+			name:      "last matching rule wins",
+			files:     []string{"src/app.js"},
+			wantTeams: []string{"frontend"},
+		},
+		{
+			name:      "docs direct child",
+			files:     []string{"docs/getting-started.md"},
+			wantTeams: []string{"docs"},
+		},
+		{
+			name:      "nested docs fall back to broader rule",
+			files:     []string{"docs/build-app/troubleshooting.md"},
+			wantTeams: []string{"global"},
+		},
+		{
+			name:      "later **/logs overrides /build/logs/",
+			files:     []string{"build/logs/app.log"},
+			wantUsers: []string{"u2"},
+		},
+		{
+			name:      "rule without owners removes ownership",
+			files:     []string{"apps/github/main.go"},
+			wantTeams: nil,
+		},
+		{
+			name:      "owners deduplicated in order of first mention",
+			files:     []string{"/docs/a.md", "web/app.js", "docs/b.md", "README.md"},
+			wantTeams: []string{"docs", "frontend", "global"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teams, users := resolveOwners(rules, tt.files)
+			if !slices.Equal(teams, tt.wantTeams) {
+				t.Errorf("teams = %v, want %v", teams, tt.wantTeams)
+			}
+			if !slices.Equal(users, tt.wantUsers) {
+				t.Errorf("users = %v, want %v", users, tt.wantUsers)
+			}
+		})
+	}
+}
+
+func TestAssignInitialCodeOwners(t *testing.T) {
+	tests := []struct {
+		name         string
+		rules        string
+		files        []string
+		wantReviewer []string
+		wantFallback []string
+	}{
+		{
+			name:         "without owners",
+			files:        []string{"main.go"},
+			wantReviewer: []string{"b1", "b2"},
+		},
+		{
+			name:         "owner team goes first and is fallback",
+			rules:        "*.js @org/frontend",
+			files:        []string{"app.js"},
+			wantReviewer: []string{"f1", "b1"},
+			wantFallback: []string{"f1"},
+		},
+		{
+			name:         "owner user from another team is fallback",
+			rules:        "*.go @p1",
+			files:        []string{"main.go"},
+			wantReviewer: []string{"p1", "b1"},
+			wantFallback: []string{"p1"},
+		},
+		{
+			name:         "owners from the author's team are not fallback",
+			rules:        "*.go @b2\n*.sql @org/backend",
+			files:        []string{"main.go", "schema.sql"},
+			wantReviewer: []string{"b2", "b1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newFakeStore(monday)
+			policy := domain.DefaultReviewPolicy()
+			policy.ReviewerStrategy = domain.ReviewerStrategyFirstN
+			st.addTeam("backend", policy, domain.User{UserID: "author"}, domain.User{UserID: "b1"}, domain.User{UserID: "b2"})
+			st.addTeam("frontend", policy, domain.User{UserID: "f1"})
+			st.addTeam("platform", policy, domain.User{UserID: "p1"})
+			rules, err := ParseCodeowners(tt.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+			st.rules = rules
+
+			pr := domain.PullRequest{PullRequestID: "pr-1", AuthorID: "author", Status: domain.PullRequestStatusOpen}
+			if _, err := st.service().assignInitial(context.Background(), &pr, st.users["author"], tt.files, monday); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := pr.ReviewerIDs(); !slices.Equal(got, tt.wantReviewer) {
+				t.Errorf("reviewers = %v, want %v", got, tt.wantReviewer)
+			}
+			if !slices.Equal(pr.FallbackReviewers, tt.wantFallback) {
+				t.Errorf("fallback reviewers = %v, want %v", pr.FallbackReviewers, tt.wantFallback)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/repo"
+)
+
+// правила конфликта интересов: пары пользователей, которые не ревьюят PR друг друга.
+// Новые назначения их соблюдают, уже сделанные не меняются, а попадают в нарушения
+type ExclusionService struct {
+	exclusions repo.ReviewExclusion
+	users      repo.User
+}
+
+func NewExclusionService(exclusions repo.ReviewExclusion, users repo.User) *ExclusionService {
+	return &ExclusionService{
+		exclusions: exclusions,
+		users:      users,
+	}
+}
+
+// Add сохраняет правило и возвращает OPEN PR, которые ему уже не соответствуют
+func (s *ExclusionService) Add(ctx context.Context, e domain.ReviewExclusion) (domain.ReviewExclusion, []domain.ExclusionViolation, error) {
+	for _, id := range []string{e.UserID, e.OtherUserID} {
+		if _, err := s.users.GetByID(ctx, id); err != nil {
+			return domain.ReviewExclusion{}, nil, err
+		}
+	}
+
+	if e.OtherUserID < e.UserID {
+		e.UserID, e.OtherUserID = e.OtherUserID, e.UserID
+	}
+
+	created, err := s.exclusions.Create(ctx, e)
+	if err != nil {
+		return domain.ReviewExclusion{}, nil, err
+	}
+
+	violations, err := s.exclusions.GetViolations(ctx, created.ID)
+	if err != nil {
+		return domain.ReviewExclusion{}, nil, err
+	}
+
+	return created, violations, nil
+}
+
+func (s *ExclusionService) List(ctx context.Context, userID string) ([]domain.ReviewExclusion, error) {
+	if userID != "" {
+		if _, err := s.users.GetByID(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.exclusions.List(ctx, userID)
+}
+
+func (s *ExclusionService) Remove(ctx context.Context, id int64) (domain.ReviewExclusion, error) {
+	return s.exclusions.Delete(ctx, id)
+}
+
+func (s *ExclusionService) GetViolations(ctx context.Context) ([]domain.ExclusionViolation, error) {
+	return s.exclusions.GetViolations(ctx, 0)
+}
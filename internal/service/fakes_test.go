@@ -0,0 +1,425 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/repo"
+	"slices"
+	"sort"
+	"time"
+)
+
+// хранилище в памяти для тестов сервисов. Репозитории — тонкие обертки над ним;
+// методы, которые тестам не нужны, достаются от встроенных nil интерфейсов и паникуют
+type fakeStore struct {
+	now time.Time
+
+	users        map[string]domain.User
+	away         []domain.Unavailability
+	teams        map[string]*fakeTeam
+	prs          map[string]domain.PullRequest
+	exclusions   [][2]string
+	rules        []domain.OwnershipRule
+	explanations []domain.AssignmentExplanation
+	events       []domain.PREvent
+	escalations  []domain.ReviewEscalation
+}
+
+type fakeTeam struct {
+	policy       domain.ReviewPolicy
+	merge        domain.MergePolicy
+	sla          domain.ReviewSLA
+	cursor       string
+	cursorWrites int
+}
+
+func newFakeStore(now time.Time) *fakeStore {
+	return &fakeStore{
+		now:   now,
+		users: make(map[string]domain.User),
+		teams: make(map[string]*fakeTeam),
+		prs:   make(map[string]domain.PullRequest),
+	}
+}
+
+func (st *fakeStore) service() *PRService {
+	return NewPRService(fakePRs{st: st}, fakeUsers{st: st}, fakeTeams{st: st}, fakeOwnership{st: st},
+		fakeExclusions{st: st}, fakeRepos{}, fakeTx{}, ClockFunc(func() time.Time { return st.now }))
+}
+
+func (st *fakeStore) addTeam(name string, policy domain.ReviewPolicy, users ...domain.User) {
+	st.teams[name] = &fakeTeam{policy: policy}
+	for _, u := range users {
+		u.TeamName = name
+		u.IsActive = true
+		st.users[u.UserID] = u
+	}
+}
+
+// OPEN PR с PENDING ревьюверами, назначенными в момент st.now
+func (st *fakeStore) addPR(id, authorID string, reviewers ...string) {
+	created := st.now.Add(-time.Duration(len(st.prs)+1) * time.Minute)
+	st.prs[id] = domain.PullRequest{
+		PullRequestID:       id,
+		AuthorID:            authorID,
+		Status:              domain.PullRequestStatusOpen,
+		Reviewers:           domain.NewReviewers(reviewers, st.now),
+		CreatedAt:           &created,
+		PullRequestMetadata: domain.PullRequestMetadata{Repository: domain.DefaultRepository},
+	}
+}
+
+func (st *fakeStore) pr(id string) domain.PullRequest {
+	return clonePR(st.prs[id])
+}
+
+func (st *fakeStore) available(u domain.User, at time.Time) bool {
+	if !u.IsActive {
+		return false
+	}
+	for _, a := range st.away {
+		if a.UserID == u.UserID && !a.StartsAt.After(at) && a.EndsAt.After(at) {
+			return false
+		}
+	}
+	return true
+}
+
+// пользователи в порядке user_id, чтобы FIRST_N был предсказуемым
+func (st *fakeStore) sortedUsers(keep func(domain.User) bool) []domain.User {
+	res := make([]domain.User, 0)
+	for _, u := range st.users {
+		if keep(u) {
+			res = append(res, u)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].UserID < res[j].UserID })
+	return res
+}
+
+func clonePR(pr domain.PullRequest) domain.PullRequest {
+	pr.Reviewers = slices.Clone(pr.Reviewers)
+	pr.FallbackReviewers = slices.Clone(pr.FallbackReviewers)
+	pr.Labels = slices.Clone(pr.Labels)
+	return pr
+}
+
+type fakePRs struct {
+	repo.PullRequest
+	st *fakeStore
+}
+
+func (r fakePRs) GetByID(_ context.Context, prID string) (domain.PullRequest, error) {
+	pr, ok := r.st.prs[prID]
+	if !ok {
+		return domain.PullRequest{}, domain.ErrNotFound
+	}
+	return clonePR(pr), nil
+}
+
+func (r fakePRs) GetByIDForUpdate(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return r.GetByID(ctx, prID)
+}
+
+func (r fakePRs) Exists(_ context.Context, prID string) (bool, error) {
+	_, ok := r.st.prs[prID]
+	return ok, nil
+}
+
+func (r fakePRs) GetIDByNumber(_ context.Context, repository, number string) (string, error) {
+	for id, pr := range r.st.prs {
+		if pr.Repository == repository && pr.Number == number {
+			return id, nil
+		}
+	}
+	return "", domain.ErrNotFound
+}
+
+func (r fakePRs) Create(_ context.Context, pr domain.PullRequest) error {
+	r.st.prs[pr.PullRequestID] = clonePR(pr)
+	return nil
+}
+
+func (r fakePRs) GetOpenReviewLoad(_ context.Context, userIDs []string) (map[string]int, error) {
+	load := make(map[string]int, len(userIDs))
+	for _, pr := range r.st.prs {
+		if pr.Status != domain.PullRequestStatusOpen {
+			continue
+		}
+		for _, rv := range pr.Reviewers {
+			if slices.Contains(userIDs, rv.UserID) {
+				load[rv.UserID]++
+			}
+		}
+	}
+	return load, nil
+}
+
+func (r fakePRs) GetRecentPairCounts(_ context.Context, authorID, exceptPRID string, window int) (map[string]int, error) {
+	counts := make(map[string]int)
+	recent := make([]domain.PullRequest, 0)
+	for _, pr := range r.st.prs {
+		if pr.AuthorID == authorID && pr.PullRequestID != exceptPRID {
+			recent = append(recent, pr)
+		}
+	}
+	sort.Slice(recent, func(i, j int) bool {
+		if !recent[i].CreatedAt.Equal(*recent[j].CreatedAt) {
+			return recent[i].CreatedAt.After(*recent[j].CreatedAt)
+		}
+		return recent[i].PullRequestID > recent[j].PullRequestID
+	})
+	for i, pr := range recent {
+		if i >= window {
+			break
+		}
+		for _, rv := range pr.Reviewers {
+			counts[rv.UserID]++
+		}
+	}
+	return counts, nil
+}
+
+func (r fakePRs) GetRecentPairCountsBatch(ctx context.Context, windows []repo.PairWindow) (map[string]map[string]int, error) {
+	res := make(map[string]map[string]int, len(windows))
+	for _, w := range windows {
+		counts, err := r.GetRecentPairCounts(ctx, w.AuthorID, w.PullRequestID, w.Window)
+		if err != nil {
+			return nil, err
+		}
+		res[w.PullRequestID] = counts
+	}
+	return res, nil
+}
+
+func (r fakePRs) GetOpenByReviewers(_ context.Context, userIDs []string) ([]domain.PullRequest, error) {
+	res := make([]domain.PullRequest, 0)
+	for _, pr := range r.st.prs {
+		if pr.Status != domain.PullRequestStatusOpen {
+			continue
+		}
+		if slices.ContainsFunc(pr.Reviewers, func(rv domain.PullRequestReviewer) bool { return slices.Contains(userIDs, rv.UserID) }) {
+			res = append(res, clonePR(pr))
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].PullRequestID < res[j].PullRequestID })
+	return res, nil
+}
+
+func (r fakePRs) ApplyReviewerChanges(_ context.Context, changes []repo.ReviewerChange, explanations []domain.AssignmentExplanation) error {
+	for _, c := range changes {
+		pr := r.st.prs[c.PullRequestID]
+		pr.FallbackReviewers = removeID(pr.FallbackReviewers, c.OldReviewerID)
+		if c.NewReviewerID == "" {
+			pr.RemoveReviewer(c.OldReviewerID)
+		} else {
+			pr.ReplaceReviewer(c.OldReviewerID, c.NewReviewerID, c.AssignedAt)
+			if c.FromFallback {
+				pr.FallbackReviewers = append(pr.FallbackReviewers, c.NewReviewerID)
+			}
+		}
+		pr.Understaffed = c.Understaffed
+		r.st.prs[c.PullRequestID] = pr
+	}
+	r.st.explanations = append(r.st.explanations, explanations...)
+	return nil
+}
+
+func (r fakePRs) ReassignReviewer(_ context.Context, prID, oldUserID, newReviewerID string, fromFallback bool, assignedAt time.Time) error {
+	pr := r.st.prs[prID]
+	pr.ReplaceReviewer(oldUserID, newReviewerID, assignedAt)
+	pr.FallbackReviewers = removeID(pr.FallbackReviewers, oldUserID)
+	if fromFallback {
+		pr.FallbackReviewers = append(pr.FallbackReviewers, newReviewerID)
+	}
+	r.st.prs[prID] = pr
+	return nil
+}
+
+func (r fakePRs) SetReviewers(_ context.Context, pr domain.PullRequest) error {
+	stored := r.st.prs[pr.PullRequestID]
+	stored.Reviewers = slices.Clone(pr.Reviewers)
+	stored.FallbackReviewers = slices.Clone(pr.FallbackReviewers)
+	stored.Understaffed = pr.Understaffed
+	r.st.prs[pr.PullRequestID] = stored
+	return nil
+}
+
+func (r fakePRs) AddAssignmentExplanation(_ context.Context, e domain.AssignmentExplanation) error {
+	r.st.explanations = append(r.st.explanations, e)
+	return nil
+}
+
+func (r fakePRs) AddEvents(_ context.Context, events []domain.PREvent) error {
+	r.st.events = append(r.st.events, events...)
+	return nil
+}
+
+func (r fakePRs) GetPendingReviews(_ context.Context, teamName string) ([]domain.PendingReview, error) {
+	res := make([]domain.PendingReview, 0)
+	for _, pr := range r.st.prs {
+		author := r.st.users[pr.AuthorID]
+		if pr.Status != domain.PullRequestStatusOpen || author.TeamName == "" || (teamName != "" && author.TeamName != teamName) {
+			continue
+		}
+		for _, rv := range pr.Reviewers {
+			if rv.State != domain.ReviewStatePending {
+				continue
+			}
+			escalated := slices.ContainsFunc(r.st.escalations, func(e domain.ReviewEscalation) bool {
+				return e.PullRequestID == pr.PullRequestID && e.ReviewerID == rv.UserID && e.AssignedAt.Equal(*rv.AssignedAt)
+			})
+			res = append(res, domain.PendingReview{
+				PullRequestID: pr.PullRequestID,
+				ReviewerID:    rv.UserID,
+				TeamName:      author.TeamName,
+				AssignedAt:    *rv.AssignedAt,
+				Escalated:     escalated,
+			})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].PullRequestID != res[j].PullRequestID {
+			return res[i].PullRequestID < res[j].PullRequestID
+		}
+		return res[i].ReviewerID < res[j].ReviewerID
+	})
+	return res, nil
+}
+
+func (r fakePRs) AddEscalation(_ context.Context, e domain.ReviewEscalation) error {
+	r.st.escalations = append(r.st.escalations, e)
+	return nil
+}
+
+type fakeUsers struct {
+	repo.User
+	st *fakeStore
+}
+
+func (r fakeUsers) GetByID(_ context.Context, userID string) (domain.User, error) {
+	u, ok := r.st.users[userID]
+	if !ok {
+		return domain.User{}, domain.ErrNotFound
+	}
+	return u, nil
+}
+
+func (r fakeUsers) GetByIDs(_ context.Context, userIDs []string) ([]domain.User, error) {
+	return r.st.sortedUsers(func(u domain.User) bool { return slices.Contains(userIDs, u.UserID) }), nil
+}
+
+func (r fakeUsers) GetByTeam(_ context.Context, teamName string) ([]domain.User, error) {
+	return r.st.sortedUsers(func(u domain.User) bool { return u.TeamName == teamName }), nil
+}
+
+func (r fakeUsers) GetActiveByTeam(_ context.Context, teamName string, at time.Time) ([]domain.User, error) {
+	return r.st.sortedUsers(func(u domain.User) bool { return u.TeamName == teamName && r.st.available(u, at) }), nil
+}
+
+func (r fakeUsers) GetAvailableByIDs(_ context.Context, userIDs []string, at time.Time) ([]domain.User, error) {
+	return r.st.sortedUsers(func(u domain.User) bool { return slices.Contains(userIDs, u.UserID) && r.st.available(u, at) }), nil
+}
+
+type fakeTeams struct {
+	repo.Team
+	st *fakeStore
+}
+
+func (r fakeTeams) team(name string) (*fakeTeam, error) {
+	t, ok := r.st.teams[name]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return t, nil
+}
+
+func (r fakeTeams) Exists(_ context.Context, teamName string) (bool, error) {
+	_, ok := r.st.teams[teamName]
+	return ok, nil
+}
+
+func (r fakeTeams) GetReviewPolicy(_ context.Context, teamName string) (domain.ReviewPolicy, error) {
+	t, err := r.team(teamName)
+	if err != nil {
+		return domain.ReviewPolicy{}, err
+	}
+	return t.policy, nil
+}
+
+func (r fakeTeams) GetMergePolicy(_ context.Context, teamName string) (domain.MergePolicy, error) {
+	t, err := r.team(teamName)
+	if err != nil {
+		return domain.MergePolicy{}, err
+	}
+	return t.merge, nil
+}
+
+func (r fakeTeams) GetReviewSLA(_ context.Context, teamName string) (domain.ReviewSLA, error) {
+	t, err := r.team(teamName)
+	if err != nil {
+		return domain.ReviewSLA{}, err
+	}
+	return t.sla, nil
+}
+
+func (r fakeTeams) GetRoundRobinCursor(_ context.Context, teamName string) (string, error) {
+	t, err := r.team(teamName)
+	if err != nil {
+		return "", err
+	}
+	return t.cursor, nil
+}
+
+func (r fakeTeams) SetRoundRobinCursor(_ context.Context, teamName, userID string) error {
+	t, err := r.team(teamName)
+	if err != nil {
+		return err
+	}
+	t.cursor = userID
+	t.cursorWrites++
+	return nil
+}
+
+type fakeExclusions struct {
+	repo.ReviewExclusion
+	st *fakeStore
+}
+
+func (r fakeExclusions) GetExcludedFor(_ context.Context, userIDs []string) (map[string][]string, error) {
+	res := make(map[string][]string)
+	for _, e := range r.st.exclusions {
+		for i, id := range e {
+			if slices.Contains(userIDs, id) {
+				res[id] = append(res[id], e[1-i])
+			}
+		}
+	}
+	return res, nil
+}
+
+type fakeOwnership struct {
+	repo.Ownership
+	st *fakeStore
+}
+
+func (r fakeOwnership) GetRules(context.Context) ([]domain.OwnershipRule, error) {
+	return r.st.rules, nil
+}
+
+// у каждого PR репозиторий без своих настроек
+type fakeRepos struct {
+	repo.Repository
+}
+
+func (fakeRepos) GetByName(_ context.Context, name string) (domain.Repository, error) {
+	return domain.Repository{Name: name}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (fakeTx) Lock(context.Context, string) error { return nil }
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-service/internal/domain"
+	"sort"
+)
+
+// переназначает все OPEN PR, где userID ревьювер, по правилам ReassignReviewer.
+// Вызывается внутри транзакции под assignmentLockKey. PR, для которых замены нет,
+// попадают в NotReassigned, остальные ошибки прерывают операцию
+func (s *PRService) reassignOpenReviews(ctx context.Context, userID string) (domain.ReassignmentReport, error) {
+	report := domain.ReassignmentReport{
+		Reassigned:    make([]domain.ReviewReassignment, 0),
+		NotReassigned: make([]domain.ReviewReassignment, 0),
+	}
+
+	prs, err := s.prs.GetByReviewer(ctx, userID, "")
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i].PullRequestID < prs[j].PullRequestID })
+
+	for _, pr := range prs {
+		if pr.Status != domain.PullRequestStatusOpen {
+			continue
+		}
+
+		_, replacedBy, err := s.reassignReviewer(ctx, pr.PullRequestID, userID, "", domain.PREventReasonDeactivation)
+		switch {
+		case err == nil:
+			report.Reassigned = append(report.Reassigned, domain.ReviewReassignment{
+				PullRequestID: pr.PullRequestID,
+				OldReviewerID: userID,
+				ReplacedBy:    replacedBy,
+			})
+		case errors.Is(err, domain.ErrNoCandidate):
+			report.NotReassigned = append(report.NotReassigned, domain.ReviewReassignment{
+				PullRequestID: pr.PullRequestID,
+				OldReviewerID: userID,
+				Error:         domain.ErrorNoCandidate,
+			})
+		case errors.Is(err, domain.ErrNotFound):
+			// у ревьювера или автора нет команды
+			report.NotReassigned = append(report.NotReassigned, domain.ReviewReassignment{
+				PullRequestID: pr.PullRequestID,
+				OldReviewerID: userID,
+				Error:         domain.ErrorNotFound,
+			})
+		default:
+			return domain.ReassignmentReport{}, err
+		}
+	}
+
+	return report, nil
+}
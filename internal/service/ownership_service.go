@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/repo"
+)
+
+type OwnershipService struct {
+	ownership repo.Ownership
+	teams     repo.Team
+	users     repo.User
+}
+
+func NewOwnershipService(ownership repo.Ownership, teams repo.Team, users repo.User) *OwnershipService {
+	return &OwnershipService{
+		ownership: ownership,
+		teams:     teams,
+		users:     users,
+	}
+}
+
+// разбирает CODEOWNERS и целиком заменяет им текущие правила
+func (s *OwnershipService) UploadCodeowners(ctx context.Context, content string) ([]domain.OwnershipRule, error) {
+	rules, err := ParseCodeowners(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkOwners(ctx, rules); err != nil {
+		return nil, err
+	}
+
+	if err := s.ownership.ReplaceRules(ctx, rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (s *OwnershipService) GetRules(ctx context.Context) ([]domain.OwnershipRule, error) {
+	return s.ownership.GetRules(ctx)
+}
+
+func (s *OwnershipService) checkOwners(ctx context.Context, rules []domain.OwnershipRule) error {
+	checked := make(map[string]struct{})
+
+	for _, rule := range rules {
+		for _, team := range rule.Teams {
+			if _, ok := checked["team:"+team]; ok {
+				continue
+			}
+			exists, err := s.teams.Exists(ctx, team)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: unknown team %q in rule %q", domain.ErrInvalidCodeowners, team, rule.Pattern)
+			}
+			checked["team:"+team] = struct{}{}
+		}
+
+		for _, userID := range rule.Users {
+			if _, ok := checked["user:"+userID]; ok {
+				continue
+			}
+			if _, err := s.users.GetByID(ctx, userID); err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					return fmt.Errorf("%w: unknown user %q in rule %q", domain.ErrInvalidCodeowners, userID, rule.Pattern)
+				}
+				return err
+			}
+			checked["user:"+userID] = struct{}{}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-service/internal/domain"
+)
+
+// жизненный цикл PR: DRAFT -> OPEN -> MERGED, DRAFT/OPEN -> CLOSED -> reopen.
+// Допустимые переходы проверяет domain.PullRequest.TransitionTo, merge — Merge
+
+// MarkReady переводит черновик в OPEN и назначает ревьюверов как при создании PR
+func (s *PRService) MarkReady(ctx context.Context, prID string, changedFiles []string) (domain.PullRequest, error) {
+	var pr domain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.tx.Lock(ctx, assignmentLockKey); err != nil {
+			return err
+		}
+
+		var err error
+		pr, err = s.prs.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			return err
+		}
+		if pr.Status != domain.PullRequestStatusDraft {
+			if pr.Status == domain.PullRequestStatusMerged {
+				return domain.ErrPRMerged
+			}
+			return domain.ErrInvalidTransition
+		}
+
+		author, err := s.users.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		if author.TeamName == "" {
+			return domain.ErrNotFound
+		}
+
+		now := s.clock.Now()
+		if err := pr.TransitionTo(domain.PullRequestStatusOpen, now); err != nil {
+			return err
+		}
+		trace, err := s.assignInitial(ctx, &pr, author, changedFiles, now)
+		if err != nil {
+			return err
+		}
+
+		if err := s.prs.Update(ctx, pr); err != nil {
+			return err
+		}
+		if err := s.prs.SetReviewers(ctx, pr); err != nil {
+			return err
+		}
+
+		events := append([]domain.PREvent{{
+			PullRequestID: prID,
+			Type:          domain.PREventReady,
+			Status:        pr.Status,
+			CreatedAt:     now,
+		}}, domain.ReviewerChangeEvents(prID, nil, pr.Reviewers, domain.PREventReasonReady, now)...)
+		if err := s.prs.AddEvents(ctx, events); err != nil {
+			return err
+		}
+
+		return s.prs.AddAssignmentExplanation(ctx, domain.AssignmentExplanation{
+			PullRequestID: prID,
+			Action:        domain.AssignmentActionReady,
+			CreatedAt:     now,
+			Candidates:    trace.candidates,
+		})
+	})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	return pr, nil
+}
+
+// Close отклоняет PR без merge, ревьюверы и их решения сохраняются
+func (s *PRService) Close(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return s.transition(ctx, prID, func(domain.PullRequest) domain.PullRequestStatus {
+		return domain.PullRequestStatusClosed
+	})
+}
+
+// Reopen возвращает закрытый PR в OPEN, а закрытый черновик — в DRAFT
+func (s *PRService) Reopen(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return s.transition(ctx, prID, func(pr domain.PullRequest) domain.PullRequestStatus {
+		switch {
+		case pr.Status != domain.PullRequestStatusClosed:
+			// переход в тот же статус недопустим, TransitionTo вернет ошибку
+			return pr.Status
+		case pr.ReadyAt == nil:
+			return domain.PullRequestStatusDraft
+		}
+		return domain.PullRequestStatusOpen
+	})
+}
+
+func (s *PRService) transition(ctx context.Context, prID string, next func(domain.PullRequest) domain.PullRequestStatus) (domain.PullRequest, error) {
+	var pr domain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prs.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		now := s.clock.Now()
+		if err := pr.TransitionTo(next(pr), now); err != nil {
+			return err
+		}
+		if err := s.prs.Update(ctx, pr); err != nil {
+			return err
+		}
+
+		event := domain.PREventReopened
+		if pr.Status == domain.PullRequestStatusClosed {
+			event = domain.PREventClosed
+		}
+		return s.prs.AddEvents(ctx, []domain.PREvent{{
+			PullRequestID: prID,
+			Type:          event,
+			Status:        pr.Status,
+			CreatedAt:     now,
+		}})
+	})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	return pr, nil
+}
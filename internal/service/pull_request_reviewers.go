@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-service/internal/domain"
+)
+
+// ручное изменение списка ревьюверов: /pullRequest/addReviewer, /pullRequest/removeReviewer,
+// PUT /pullRequest/reviewers. Новые ревьюверы должны быть активны, не быть автором
+// и не быть с ним в паре исключений, число ревьюверов ограничено max_reviewers команды автора
+
+func (s *PRService) AddReviewer(ctx context.Context, prID, userID string) (domain.PullRequest, error) {
+	return s.editReviewers(ctx, prID, func(pr domain.PullRequest) ([]string, error) {
+		if pr.HasReviewer(userID) {
+			return nil, domain.ErrAlreadyAssigned
+		}
+		return append(pr.ReviewerIDs(), userID), nil
+	})
+}
+
+func (s *PRService) RemoveReviewer(ctx context.Context, prID, userID string) (domain.PullRequest, error) {
+	return s.editReviewers(ctx, prID, func(pr domain.PullRequest) ([]string, error) {
+		if !pr.HasReviewer(userID) {
+			return nil, domain.ErrNotAssigned
+		}
+		return removeID(pr.ReviewerIDs(), userID), nil
+	})
+}
+
+func (s *PRService) SetReviewers(ctx context.Context, prID string, userIDs []string) (domain.PullRequest, error) {
+	return s.editReviewers(ctx, prID, func(domain.PullRequest) ([]string, error) {
+		return userIDs, nil
+	})
+}
+
+func (s *PRService) editReviewers(ctx context.Context, prID string, edit func(domain.PullRequest) ([]string, error)) (domain.PullRequest, error) {
+	var pr domain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.tx.Lock(ctx, assignmentLockKey); err != nil {
+			return err
+		}
+
+		current, err := s.prs.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			return err
+		}
+		if err := current.CheckOpen(); err != nil {
+			return err
+		}
+
+		reviewers, err := edit(current)
+		if err != nil {
+			return err
+		}
+
+		pr, err = s.applyReviewers(ctx, current, reviewers)
+		return err
+	})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	return pr, nil
+}
+
+// проверяет новых ревьюверов и сохраняет список. Ревьюверы не из команды автора
+// считаются запасными, у оставшихся признак и решение сохраняются
+func (s *PRService) applyReviewers(ctx context.Context, pr domain.PullRequest, reviewers []string) (domain.PullRequest, error) {
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	policy, err := s.reviewPolicyFor(ctx, pr, author.TeamName)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if len(reviewers) > policy.MaxReviewers {
+		return domain.PullRequest{}, domain.ErrTooManyReviewers
+	}
+
+	assigned := make(map[string]domain.PullRequestReviewer, len(pr.Reviewers))
+	for _, rv := range pr.Reviewers {
+		assigned[rv.UserID] = rv
+	}
+	wasFallback := make(map[string]struct{}, len(pr.FallbackReviewers))
+	for _, id := range pr.FallbackReviewers {
+		wasFallback[id] = struct{}{}
+	}
+
+	conflicts, err := s.exclusion.GetExcludedFor(ctx, []string{pr.AuthorID})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	conflicting := make(map[string]struct{}, len(conflicts[pr.AuthorID]))
+	for _, id := range conflicts[pr.AuthorID] {
+		conflicting[id] = struct{}{}
+	}
+
+	now := s.clock.Now()
+	trace := newAssignmentTrace()
+	fallback := make([]string, 0)
+	updated := make([]domain.PullRequestReviewer, 0, len(reviewers))
+	for _, id := range reviewers {
+		if id == pr.AuthorID {
+			return domain.PullRequest{}, domain.ErrReviewerIsAuthor
+		}
+
+		if rv, ok := assigned[id]; ok {
+			updated = append(updated, rv)
+			if _, ok := wasFallback[id]; ok {
+				fallback = append(fallback, id)
+			}
+			continue
+		}
+
+		u, err := s.users.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.PullRequest{}, domain.ErrReviewerNotFound
+			}
+			return domain.PullRequest{}, err
+		}
+		if !u.IsActive {
+			return domain.PullRequest{}, domain.ErrReviewerInactive
+		}
+		if _, ok := conflicting[id]; ok {
+			return domain.PullRequest{}, domain.ErrConflictOfInterest
+		}
+		if u.TeamName != author.TeamName {
+			fallback = append(fallback, id)
+		}
+		updated = append(updated, domain.NewReviewers([]string{id}, now)...)
+		trace.pick(id, u.TeamName, domain.CandidateReasonRequested, "")
+	}
+
+	before := pr.Reviewers
+	pr.Reviewers = updated
+	pr.FallbackReviewers = fallback
+	pr.Understaffed = len(reviewers) < policy.MinReviewers
+
+	if err := s.prs.SetReviewers(ctx, pr); err != nil {
+		return domain.PullRequest{}, err
+	}
+	if err := s.prs.AddEvents(ctx, domain.ReviewerChangeEvents(pr.PullRequestID, before, pr.Reviewers, domain.PREventReasonManual, now)); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	if len(trace.candidates) > 0 {
+		err = s.prs.AddAssignmentExplanation(ctx, domain.AssignmentExplanation{
+			PullRequestID: pr.PullRequestID,
+			Action:        domain.AssignmentActionManual,
+			CreatedAt:     now,
+			Candidates:    trace.candidates,
+		})
+		if err != nil {
+			return domain.PullRequest{}, err
+		}
+	}
+
+	return pr, nil
+}
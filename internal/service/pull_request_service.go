@@ -2,26 +2,98 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"pr-reviewer-service/internal/domain"
 	"pr-reviewer-service/internal/repo"
+	"slices"
 	"time"
 )
 
+// все назначения ревьюверов идут под одним локом, иначе параллельные запросы
+// видят одинаковую нагрузку и выбирают одних и тех же людей
+const assignmentLockKey = "reviewer-assignment"
+
 type PRService struct {
-	prs   repo.PullRequest
-	users repo.User
-	teams repo.Team
+	prs       repo.PullRequest
+	users     repo.User
+	teams     repo.Team
+	ownership repo.Ownership
+	exclusion repo.ReviewExclusion
+	repos     repo.Repository
+	tx        repo.Transactor
+	clock     Clock
+	selectors map[domain.ReviewerStrategy]ReviewerSelector
 }
 
-func NewPRService(prs repo.PullRequest, users repo.User, teams repo.Team) *PRService {
+func NewPRService(prs repo.PullRequest, users repo.User, teams repo.Team, ownership repo.Ownership, exclusion repo.ReviewExclusion, repos repo.Repository, tx repo.Transactor, clock Clock) *PRService {
 	return &PRService{
-		prs:   prs,
-		users: users,
-		teams: teams,
+		prs:       prs,
+		users:     users,
+		teams:     teams,
+		ownership: ownership,
+		exclusion: exclusion,
+		repos:     repos,
+		tx:        tx,
+		clock:     clock,
+		selectors: newReviewerSelectors(prs, teams),
+	}
+}
+
+type CreatePRParams struct {
+	PullRequestID string
+	// номер внутри Metadata.Repository, по умолчанию совпадает с PullRequestID
+	Number          string
+	PullRequestName string
+	AuthorID        string
+	// пути измененных файлов, по ним через CODEOWNERS выбираются команды-владельцы
+	ChangedFiles []string
+	// метки PR, предпочтение ревьюверам с такими тегами
+	Labels []string
+	// черновик создается без ревьюверов, они назначаются в MarkReady
+	Draft bool
+	// репозиторий, ветки, ссылка, описание и размер изменений
+	Metadata domain.PullRequestMetadata
+}
+
+func (s *PRService) Create(ctx context.Context, params CreatePRParams) (domain.PullRequest, error) {
+	var pr domain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.tx.Lock(ctx, assignmentLockKey); err != nil {
+			return err
+		}
+
+		var err error
+		pr, err = s.create(ctx, params)
+		return err
+	})
+	if err != nil {
+		return domain.PullRequest{}, err
 	}
+
+	return pr, nil
 }
 
-func (s *PRService) Create(ctx context.Context, prID, prName, authorID string) (domain.PullRequest, error) {
+// pull_request_id уникален глобально, а не внутри репозитория: ключи вида
+// repository#number для разных репозиториев строит /repository/pullRequest/create
+func (s *PRService) create(ctx context.Context, params CreatePRParams) (domain.PullRequest, error) {
+	prID, authorID := params.PullRequestID, params.AuthorID
+	params.Labels = domain.NormalizeTags(params.Labels)
+	if params.Metadata.FilesChanged == 0 {
+		params.Metadata.FilesChanged = len(params.ChangedFiles)
+	}
+	if params.Metadata.Repository == "" {
+		params.Metadata.Repository = domain.DefaultRepository
+	}
+	if params.Number == "" {
+		params.Number = prID
+	}
+	now := s.clock.Now()
+
+	if _, err := s.repos.GetByName(ctx, params.Metadata.Repository); err != nil {
+		return domain.PullRequest{}, err
+	}
 
 	exists, err := s.prs.Exists(ctx, prID)
 	if err != nil {
@@ -30,6 +102,13 @@ func (s *PRService) Create(ctx context.Context, prID, prName, authorID string) (
 	if exists {
 		return domain.PullRequest{}, domain.ErrPRExists
 	}
+	_, err = s.prs.GetIDByNumber(ctx, params.Metadata.Repository, params.Number)
+	if err == nil {
+		return domain.PullRequest{}, domain.ErrPRExists
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return domain.PullRequest{}, err
+	}
 
 	author, err := s.users.GetByID(ctx, authorID)
 	if err != nil {
@@ -39,80 +118,340 @@ func (s *PRService) Create(ctx context.Context, prID, prName, authorID string) (
 		return domain.PullRequest{}, domain.ErrNotFound
 	}
 
-	candidates, err := s.users.GetActiveByTeam(ctx, author.TeamName)
-	if err != nil {
-		return domain.PullRequest{}, err
+	pr := domain.PullRequest{
+		PullRequestID:   prID,
+		Number:          params.Number,
+		PullRequestName: params.PullRequestName,
+		AuthorID:        authorID,
+		Status:          domain.PullRequestStatusDraft,
+		Reviewers:       []domain.PullRequestReviewer{},
+		Labels:          params.Labels,
+		CreatedAt:       &now,
+		MergedAt:        nil,
+
+		PullRequestMetadata: params.Metadata,
 	}
 
-	reviewers := make([]string, 0, 2)
-	for _, u := range candidates {
-		if u.UserID == authorID {
-			continue
+	if params.Draft {
+		if err := s.prs.Create(ctx, pr); err != nil {
+			return domain.PullRequest{}, err
 		}
-		reviewers = append(reviewers, u.UserID)
-		if len(reviewers) == 2 {
-			break
+		if err := s.prs.AddEvents(ctx, []domain.PREvent{createdEvent(pr, now)}); err != nil {
+			return domain.PullRequest{}, err
 		}
+		return pr, nil
 	}
 
-	now := time.Now().UTC()
-	pr := domain.PullRequest{
-		PullRequestID:     prID,
-		PullRequestName:   prName,
-		AuthorID:          authorID,
-		Status:            domain.PullRequestStatusOpen,
-		AssignedReviewers: reviewers,
-		CreatedAt:         &now,
-		MergedAt:          nil,
+	if err := pr.TransitionTo(domain.PullRequestStatusOpen, now); err != nil {
+		return domain.PullRequest{}, err
+	}
+	trace, err := s.assignInitial(ctx, &pr, author, params.ChangedFiles, now)
+	if err != nil {
+		return domain.PullRequest{}, err
 	}
 
 	if err := s.prs.Create(ctx, pr); err != nil {
 		return domain.PullRequest{}, err
 	}
 
+	err = s.prs.AddAssignmentExplanation(ctx, domain.AssignmentExplanation{
+		PullRequestID: prID,
+		Action:        domain.AssignmentActionCreate,
+		CreatedAt:     now,
+		Candidates:    trace.candidates,
+	})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	events := append([]domain.PREvent{createdEvent(pr, now)},
+		domain.ReviewerChangeEvents(prID, nil, pr.Reviewers, domain.PREventReasonCreate, now)...)
+	if err := s.prs.AddEvents(ctx, events); err != nil {
+		return domain.PullRequest{}, err
+	}
+
 	return pr, nil
 }
 
-func (s *PRService) Merge(ctx context.Context, prID string) (domain.PullRequest, error) {
-	pr, err := s.prs.GetByID(ctx, prID)
+// политика команды автора с настройками репозитория PR: лимиты репозитория
+// заменяют командные, команда-владелец становится первой запасной командой
+func (s *PRService) reviewPolicyFor(ctx context.Context, pr domain.PullRequest, authorTeam string) (domain.ReviewPolicy, error) {
+	policy, err := s.teams.GetReviewPolicy(ctx, authorTeam)
+	if err != nil {
+		return domain.ReviewPolicy{}, err
+	}
+
+	repository, err := s.repos.GetByName(ctx, pr.Repository)
+	if err != nil {
+		return domain.ReviewPolicy{}, err
+	}
+	policy = repository.ReviewPolicy.Apply(policy)
+
+	owner := repository.TeamName
+	if owner != "" && owner != authorTeam && !slices.Contains(policy.FallbackTeams, owner) {
+		policy.FallbackTeams = append([]string{owner}, policy.FallbackTeams...)
+	}
+	return policy, nil
+}
+
+// assignInitial выбирает ревьюверов для PR, который становится готов к ревью:
+// владельцы по CODEOWNERS, затем команда автора и ее запасные команды
+func (s *PRService) assignInitial(ctx context.Context, pr *domain.PullRequest, author domain.User, changedFiles []string, now time.Time) (*assignmentTrace, error) {
+	authorID := author.UserID
+
+	policy, err := s.reviewPolicyFor(ctx, *pr, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := s.prs.GetRecentPairCounts(ctx, authorID, pr.PullRequestID, policy.PairDiversityWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	pick := poolPick{
+		teams:   append([]string{author.TeamName}, policy.FallbackTeams...),
+		primary: map[string]struct{}{author.TeamName: {}},
+		exclude: map[string]struct{}{authorID: {}},
+		labels:  pr.Labels,
+		recent:  recent,
+		now:     now,
+		trace:   newAssignmentTrace(),
+	}
+	pick.trace.skip(authorID, author.TeamName, domain.CandidateReasonAuthor, "")
+	if err := s.excludeConflicts(ctx, authorID, pick.exclude, pick.trace); err != nil {
+		return nil, err
+	}
+
+	reviewers := make([]string, 0, policy.MaxReviewers)
+	var fallback []string
+	if len(changedFiles) > 0 {
+		rules, err := s.ownership.GetRules(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ownerTeams, ownerUsers := resolveOwners(rules, changedFiles)
+		if len(ownerTeams) > 0 || len(ownerUsers) > 0 {
+			// владельцы путей идут раньше команды автора, но основной остается
+			// команда автора: ревьюверы из других команд считаются запасными
+			pick.teams = append(append(ownerTeams, author.TeamName), policy.FallbackTeams...)
+
+			owners, err := s.pickOwnerUsers(ctx, ownerUsers, pick, policy.MaxReviewers)
+			if err != nil {
+				return nil, err
+			}
+			labels := tagSet(pr.Labels)
+			for _, u := range owners {
+				reviewers = append(reviewers, u.UserID)
+				if _, ok := pick.primary[u.TeamName]; !ok {
+					fallback = append(fallback, u.UserID)
+				}
+				pick.exclude[u.UserID] = struct{}{}
+				pick.matched = pick.matched || hasTag(u, labels)
+			}
+		}
+	}
+
+	pick.n = policy.MaxReviewers - len(reviewers)
+	res, err := s.pickFromPools(ctx, pick)
+	if err != nil {
+		return nil, err
+	}
+	reviewers = append(reviewers, res.picked...)
+
+	// кандидаты были, но все достигли лимита max_open_reviews
+	if len(reviewers) == 0 && pick.n > 0 && res.saturated > 0 {
+		return nil, domain.ErrNoCandidate
+	}
+
+	understaffed := len(reviewers) < policy.MinReviewers
+	if understaffed && !policy.AllowUnderstaffed {
+		return nil, domain.ErrNoCandidate
+	}
+
+	pr.Reviewers = domain.NewReviewers(reviewers, now)
+	pr.FallbackReviewers = append(fallback, res.fallback...)
+	pr.Understaffed = understaffed
+
+	return pick.trace, nil
+}
+
+// Merge проверяет merge политику команды автора: без force невыполненные условия
+// возвращаются как *domain.MergeBlockedError, с force они сохраняются в PR.
+// Строка PR блокируется, чтобы решения ревьюверов не менялись во время проверки
+func (s *PRService) Merge(ctx context.Context, prID string, force bool) (domain.PullRequest, error) {
+	var pr domain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prs.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if pr.Status == domain.PullRequestStatusMerged {
+			return nil
+		}
+
+		// DRAFT и CLOSED сначала нужно перевести в OPEN
+		now := s.clock.Now()
+		if err := pr.TransitionTo(domain.PullRequestStatusMerged, now); err != nil {
+			return err
+		}
+
+		unmet, err := s.unmetMergeConditions(ctx, pr)
+		if err != nil {
+			return err
+		}
+		event := domain.PREvent{
+			PullRequestID: prID,
+			Type:          domain.PREventMerged,
+			Status:        pr.Status,
+			CreatedAt:     now,
+		}
+		if len(unmet) > 0 {
+			if !force {
+				return &domain.MergeBlockedError{Conditions: unmet}
+			}
+			pr.ForceMerged = true
+			pr.MergeBypassed = unmet
+			event.Reason = domain.PREventReasonForce
+		}
+
+		if err := s.prs.Update(ctx, pr); err != nil {
+			return err
+		}
+		return s.prs.AddEvents(ctx, []domain.PREvent{event})
+	})
 	if err != nil {
 		return domain.PullRequest{}, err
 	}
 
-	if pr.Status == domain.PullRequestStatusMerged {
-		return pr, nil
+	return pr, nil
+}
+
+// условия merge политики команды автора, которые PR пока не выполняет.
+// Учитываются только APPROVED текущих ревьюверов
+func (s *PRService) unmetMergeConditions(ctx context.Context, pr domain.PullRequest) ([]string, error) {
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if author.TeamName == "" {
+		return nil, nil
 	}
 
-	now := time.Now().UTC()
-	pr.Status = domain.PullRequestStatusMerged
-	pr.MergedAt = &now
+	policy, err := s.teams.GetMergePolicy(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	unmet := make([]string, 0)
+	if approvals := pr.Approvals(); approvals < policy.RequiredApprovals {
+		unmet = append(unmet, fmt.Sprintf("%d of %d required approvals", approvals, policy.RequiredApprovals))
+	}
+
+	// тимлид мог быть удален (team_lead_id обнулится), тогда условие не действует
+	if policy.RequireLeadApproval && policy.TeamLeadID != "" && policy.TeamLeadID != pr.AuthorID {
+		approved := false
+		for _, rv := range pr.Reviewers {
+			if rv.UserID == policy.TeamLeadID && rv.State == domain.ReviewStateApproved {
+				approved = true
+			}
+		}
+		if !approved {
+			unmet = append(unmet, fmt.Sprintf("approval from team lead %s is required", policy.TeamLeadID))
+		}
+	}
+
+	return unmet, nil
+}
+
+// Review сохраняет решение ревьювера. COMMENTED не отменяет уже принятое
+// APPROVED или CHANGES_REQUESTED, а только обновляет время ответа
+func (s *PRService) Review(ctx context.Context, prID, reviewerID string, state domain.ReviewState) (domain.PullRequest, error) {
+	var pr domain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prs.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			return err
+		}
+		if err := pr.CheckOpen(); err != nil {
+			return err
+		}
 
-	if err := s.prs.Update(ctx, pr); err != nil {
+		for i, rv := range pr.Reviewers {
+			if rv.UserID != reviewerID {
+				continue
+			}
+
+			if state == domain.ReviewStateCommented &&
+				(rv.State == domain.ReviewStateApproved || rv.State == domain.ReviewStateChangesRequested) {
+				state = rv.State
+			}
+			now := s.clock.Now()
+			if err := s.prs.SetReviewState(ctx, prID, reviewerID, state, now); err != nil {
+				return err
+			}
+			pr.Reviewers[i].State = state
+			pr.Reviewers[i].ReviewedAt = &now
+
+			return s.prs.AddEvents(ctx, []domain.PREvent{{
+				PullRequestID: prID,
+				Type:          domain.PREventReviewed,
+				ReviewerID:    reviewerID,
+				State:         state,
+				CreatedAt:     now,
+			}})
+		}
+		return domain.ErrNotAssigned
+	})
+	if err != nil {
 		return domain.PullRequest{}, err
 	}
 
 	return pr, nil
 }
 
-func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (domain.PullRequest, string, error) {
-	pr, err := s.prs.GetByID(ctx, prID)
+// ReassignReviewer заменяет ревьювера oldReviewerID. Если targetID задан, замена —
+// этот пользователь, иначе кандидат подбирается как при создании PR
+func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldReviewerID, targetID string) (domain.PullRequest, string, error) {
+	var (
+		pr            domain.PullRequest
+		newReviewerID string
+	)
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.tx.Lock(ctx, assignmentLockKey); err != nil {
+			return err
+		}
+
+		var err error
+		pr, newReviewerID, err = s.reassignReviewer(ctx, prID, oldReviewerID, targetID, domain.PREventReasonReassign)
+		return err
+	})
 	if err != nil {
 		return domain.PullRequest{}, "", err
 	}
 
-	if pr.Status == domain.PullRequestStatusMerged {
-		return domain.PullRequest{}, "", domain.ErrPRMerged
+	return pr, newReviewerID, nil
+}
+
+// reason попадает в историю PR: кто инициировал замену
+func (s *PRService) reassignReviewer(ctx context.Context, prID, oldReviewerID, targetID string, reason domain.PREventReason) (domain.PullRequest, string, error) {
+	pr, err := s.prs.GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, "", err
 	}
 
-	assigned := false
-	for _, r := range pr.AssignedReviewers {
-		if r == oldReviewerID {
-			assigned = true
-			break
-		}
+	if err := pr.CheckOpen(); err != nil {
+		return domain.PullRequest{}, "", err
 	}
 
-	if !assigned {
+	if !pr.HasReviewer(oldReviewerID) {
 		return domain.PullRequest{}, "", domain.ErrNotAssigned
 	}
 
@@ -124,42 +463,260 @@ func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldReviewerID st
 		return domain.PullRequest{}, "", domain.ErrNotFound
 	}
 
-	candidates, err := s.users.GetActiveByTeam(ctx, reviewer.TeamName)
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
 	if err != nil {
 		return domain.PullRequest{}, "", err
 	}
 
-	assignedSet := make(map[string]struct{}, len(pr.AssignedReviewers))
-	for _, id := range pr.AssignedReviewers {
-		assignedSet[id] = struct{}{}
+	policy, err := s.reviewPolicyFor(ctx, pr, author.TeamName)
+	if err != nil {
+		return domain.PullRequest{}, "", err
 	}
 
-	var newReviewerID string
-	for _, u := range candidates {
-		if u.UserID == oldReviewerID {
-			continue
+	now := s.clock.Now()
+	trace := newAssignmentTrace()
+	trace.skip(pr.AuthorID, author.TeamName, domain.CandidateReasonAuthor, "")
+	trace.skip(oldReviewerID, reviewer.TeamName, domain.CandidateReasonReplaced, "")
+	explanation := domain.AssignmentExplanation{
+		PullRequestID:      prID,
+		Action:             domain.AssignmentActionReassign,
+		ReplacedReviewerID: oldReviewerID,
+		CreatedAt:          now,
+	}
+
+	if targetID != "" {
+		target, err := s.checkReassignTarget(ctx, pr, reviewer, targetID)
+		if err != nil {
+			return domain.PullRequest{}, "", err
 		}
-		if _, already := assignedSet[u.UserID]; already {
+		trace.pick(target.UserID, target.TeamName, domain.CandidateReasonRequested, "")
+		explanation.Candidates = trace.candidates
+
+		return s.replaceReviewer(ctx, pr, oldReviewerID, target.UserID, target.TeamName != author.TeamName, explanation, reason)
+	}
+
+	// автора тоже исключаем: он мог оказаться в команде заменяемого ревьювера
+	exclude := make(map[string]struct{}, len(pr.Reviewers)+1)
+	exclude[pr.AuthorID] = struct{}{}
+	for _, rv := range pr.Reviewers {
+		exclude[rv.UserID] = struct{}{}
+	}
+	if err := s.excludeConflicts(ctx, pr.AuthorID, exclude, trace); err != nil {
+		return domain.PullRequest{}, "", err
+	}
+
+	// сначала команда заменяемого ревьювера, затем команда автора и ее запасные команды
+	pools := append([]string{reviewer.TeamName, author.TeamName}, policy.FallbackTeams...)
+	// метку закрывает любой из оставшихся ревьюверов, тогда замена может быть любой
+	labels := tagSet(pr.Labels)
+	matched := false
+	for _, id := range pr.ReviewerIDs() {
+		if id == oldReviewerID {
 			continue
 		}
-		newReviewerID = u.UserID
-		break
+		u, err := s.users.GetByID(ctx, id)
+		if err != nil {
+			return domain.PullRequest{}, "", err
+		}
+		trace.skip(id, u.TeamName, domain.CandidateReasonAlreadyAssigned, "")
+		matched = matched || hasTag(u, labels)
 	}
 
-	if newReviewerID == "" {
-		return domain.PullRequest{}, "", domain.ErrNoCandidate
+	recent, err := s.prs.GetRecentPairCounts(ctx, pr.AuthorID, prID, policy.PairDiversityWindow)
+	if err != nil {
+		return domain.PullRequest{}, "", err
 	}
 
-	for i, id := range pr.AssignedReviewers {
-		if id == oldReviewerID {
-			pr.AssignedReviewers[i] = newReviewerID
-			break
+	res, err := s.pickFromPools(ctx, poolPick{
+		teams:   pools,
+		primary: map[string]struct{}{author.TeamName: {}},
+		exclude: exclude,
+		labels:  pr.Labels,
+		matched: matched,
+		recent:  recent,
+		n:       1,
+		now:     now,
+		trace:   trace,
+	})
+	if err != nil {
+		return domain.PullRequest{}, "", err
+	}
+
+	explanation.Candidates = trace.candidates
+	if len(res.picked) == 0 {
+		// все подходящие кандидаты достигли лимита: снимать ревьювера нельзя
+		if res.saturated > 0 {
+			return domain.PullRequest{}, "", domain.ErrNoCandidate
 		}
+		return s.dropReviewer(ctx, pr, policy, oldReviewerID, explanation, reason)
 	}
 
-	if err := s.prs.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID); err != nil {
+	return s.replaceReviewer(ctx, pr, oldReviewerID, res.picked[0], len(res.fallback) > 0, explanation, reason)
+}
+
+// заменяет oldReviewerID на newReviewerID и сохраняет объяснение назначения
+func (s *PRService) replaceReviewer(ctx context.Context, pr domain.PullRequest, oldReviewerID, newReviewerID string, fromFallback bool, explanation domain.AssignmentExplanation, reason domain.PREventReason) (domain.PullRequest, string, error) {
+	pr.ReplaceReviewer(oldReviewerID, newReviewerID, explanation.CreatedAt)
+	pr.FallbackReviewers = removeID(pr.FallbackReviewers, oldReviewerID)
+	if fromFallback {
+		pr.FallbackReviewers = append(pr.FallbackReviewers, newReviewerID)
+	}
+
+	if err := s.prs.ReassignReviewer(ctx, pr.PullRequestID, oldReviewerID, newReviewerID, fromFallback, explanation.CreatedAt); err != nil {
+		return domain.PullRequest{}, "", err
+	}
+	if err := s.prs.AddAssignmentExplanation(ctx, explanation); err != nil {
+		return domain.PullRequest{}, "", err
+	}
+	err := s.prs.AddEvents(ctx, []domain.PREvent{{
+		PullRequestID: pr.PullRequestID,
+		Type:          domain.PREventReviewerReassigned,
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+		Reason:        reason,
+		CreatedAt:     explanation.CreatedAt,
+	}})
+	if err != nil {
 		return domain.PullRequest{}, "", err
 	}
 
 	return pr, newReviewerID, nil
 }
+
+// явно выбранная замена должна существовать, быть активной, не быть автором,
+// уже назначенным ревьювером или в паре исключений с автором и состоять в команде заменяемого ревьювера
+func (s *PRService) checkReassignTarget(ctx context.Context, pr domain.PullRequest, reviewer domain.User, targetID string) (domain.User, error) {
+	target, err := s.users.GetByID(ctx, targetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.User{}, domain.ErrReviewerNotFound
+		}
+		return domain.User{}, err
+	}
+
+	if target.UserID == pr.AuthorID {
+		return domain.User{}, domain.ErrReviewerIsAuthor
+	}
+	if pr.HasReviewer(target.UserID) {
+		return domain.User{}, domain.ErrAlreadyAssigned
+	}
+	if !target.IsActive {
+		return domain.User{}, domain.ErrReviewerInactive
+	}
+	conflict, err := s.inConflict(ctx, pr.AuthorID, target.UserID)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if conflict {
+		return domain.User{}, domain.ErrConflictOfInterest
+	}
+	if target.TeamName != reviewer.TeamName {
+		return domain.User{}, domain.ErrTeamMismatch
+	}
+
+	return target, nil
+}
+
+// добавляет в exclude тех, кто в паре исключений с автором, и отмечает их в объяснении
+func (s *PRService) excludeConflicts(ctx context.Context, authorID string, exclude map[string]struct{}, trace *assignmentTrace) error {
+	conflicts, err := s.exclusion.GetExcludedFor(ctx, []string{authorID})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range conflicts[authorID] {
+		if _, ok := exclude[id]; ok {
+			continue
+		}
+		exclude[id] = struct{}{}
+		trace.skip(id, "", domain.CandidateReasonConflict, "excluded with author")
+	}
+	return nil
+}
+
+func (s *PRService) inConflict(ctx context.Context, authorID, reviewerID string) (bool, error) {
+	conflicts, err := s.exclusion.GetExcludedFor(ctx, []string{authorID})
+	if err != nil {
+		return false, err
+	}
+
+	for _, id := range conflicts[authorID] {
+		if id == reviewerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// замены не нашлось: команда автора с allow_understaffed разрешает просто снять ревьювера,
+// иначе NO_CANDIDATE
+func (s *PRService) dropReviewer(ctx context.Context, pr domain.PullRequest, policy domain.ReviewPolicy, oldReviewerID string, explanation domain.AssignmentExplanation, reason domain.PREventReason) (domain.PullRequest, string, error) {
+	if !policy.AllowUnderstaffed {
+		return domain.PullRequest{}, "", domain.ErrNoCandidate
+	}
+
+	pr.RemoveReviewer(oldReviewerID)
+	pr.FallbackReviewers = removeID(pr.FallbackReviewers, oldReviewerID)
+	pr.Understaffed = len(pr.Reviewers) < policy.MinReviewers
+
+	if err := s.prs.SetReviewers(ctx, pr); err != nil {
+		return domain.PullRequest{}, "", err
+	}
+	if err := s.prs.AddAssignmentExplanation(ctx, explanation); err != nil {
+		return domain.PullRequest{}, "", err
+	}
+	err := s.prs.AddEvents(ctx, []domain.PREvent{{
+		PullRequestID: pr.PullRequestID,
+		Type:          domain.PREventReviewerRemoved,
+		ReviewerID:    oldReviewerID,
+		Reason:        reason,
+		CreatedAt:     explanation.CreatedAt,
+	}})
+	if err != nil {
+		return domain.PullRequest{}, "", err
+	}
+
+	return pr, "", nil
+}
+
+func (s *PRService) GetAssignmentExplanations(ctx context.Context, prID string) ([]domain.AssignmentExplanation, error) {
+	exists, err := s.prs.Exists(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+
+	return s.prs.GetAssignmentExplanations(ctx, prID)
+}
+
+// GetTimeline возвращает историю PR в порядке событий
+func (s *PRService) GetTimeline(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	exists, err := s.prs.Exists(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+
+	return s.prs.GetEvents(ctx, prID)
+}
+
+func createdEvent(pr domain.PullRequest, at time.Time) domain.PREvent {
+	return domain.PREvent{
+		PullRequestID: pr.PullRequestID,
+		Type:          domain.PREventCreated,
+		Status:        pr.Status,
+		CreatedAt:     at,
+	}
+}
+
+// ResolveNumber возвращает pull_request_id PR с номером number в репозитории
+func (s *PRService) ResolveNumber(ctx context.Context, repository, number string) (string, error) {
+	if _, err := s.repos.GetByName(ctx, repository); err != nil {
+		return "", err
+	}
+	return s.prs.GetIDByNumber(ctx, repository, number)
+}
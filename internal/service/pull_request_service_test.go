@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-service/internal/domain"
+	"testing"
+)
+
+func TestCreatePRKeys(t *testing.T) {
+	st := newFakeStore(monday)
+	st.addTeam("backend", domain.ReviewPolicy{}, domain.User{UserID: "u1"})
+	s := st.service()
+
+	legacy := func(prID, repository string) CreatePRParams {
+		return CreatePRParams{PullRequestID: prID, AuthorID: "u1", Draft: true,
+			Metadata: domain.PullRequestMetadata{Repository: repository}}
+	}
+	scoped := func(repository, number string) CreatePRParams {
+		p := legacy(domain.PullRequestKey(repository, number), repository)
+		p.Number = number
+		return p
+	}
+
+	if _, err := s.Create(context.Background(), legacy("PR-1", "")); err != nil {
+		t.Fatalf("legacy create: %v", err)
+	}
+	if got := st.pr("PR-1"); got.Repository != domain.DefaultRepository || got.Number != "PR-1" {
+		t.Fatalf("legacy PR = %s/%s, want %s/PR-1", got.Repository, got.Number, domain.DefaultRepository)
+	}
+
+	// pull_request_id устаревшего пути уникален глобально, а не внутри репозитория
+	if _, err := s.Create(context.Background(), legacy("PR-1", "other")); !errors.Is(err, domain.ErrPRExists) {
+		t.Fatalf("legacy create in other repository: err = %v, want %v", err, domain.ErrPRExists)
+	}
+
+	// номер в другом репозитории заводится через ключ repository#number
+	if _, err := s.Create(context.Background(), scoped("other", "PR-1")); err != nil {
+		t.Fatalf("scoped create: %v", err)
+	}
+	if _, err := s.Create(context.Background(), scoped("other", "PR-1")); !errors.Is(err, domain.ErrPRExists) {
+		t.Fatalf("scoped create twice: err = %v, want %v", err, domain.ErrPRExists)
+	}
+
+	// ключ, совпадающий с repository#number, тоже занят
+	if _, err := s.Create(context.Background(), legacy("other#PR-1", "")); !errors.Is(err, domain.ErrPRExists) {
+		t.Fatalf("legacy create with scoped key: err = %v, want %v", err, domain.ErrPRExists)
+	}
+	// как и номер, уже занятый в репозитории
+	if _, err := s.Create(context.Background(), scoped("other", "7")); err != nil {
+		t.Fatalf("scoped create: %v", err)
+	}
+	if _, err := s.Create(context.Background(), legacy("7", "other")); !errors.Is(err, domain.ErrPRExists) {
+		t.Fatalf("legacy create with taken number: err = %v, want %v", err, domain.ErrPRExists)
+	}
+}
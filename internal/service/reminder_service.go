@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/notify"
+	"pr-reviewer-service/internal/repo"
+	"pr-reviewer-service/internal/scheduler"
+	"time"
+)
+
+// имя периодической задачи напоминаний, ручной запуск берет ее лок
+const ReminderJob = "remind-stale-reviews"
+
+// JobLocker — лок задачи планировщика, см. scheduler.Locker
+type JobLocker interface {
+	TryLock(ctx context.Context, key string) (unlock func(), ok bool, err error)
+}
+
+// напоминания ревьюверам, которые долго не отвечают на PR
+type ReminderService struct {
+	reminders repo.Reminder
+	prs       repo.PullRequest
+	users     repo.User
+	notifier  notify.Notifier
+	locker    JobLocker
+	clock     Clock
+
+	// через сколько после назначения напоминать и как часто повторять
+	after    time.Duration
+	interval time.Duration
+}
+
+func NewReminderService(reminders repo.Reminder, prs repo.PullRequest, users repo.User, notifier notify.Notifier, locker JobLocker, clock Clock, after, interval time.Duration) *ReminderService {
+	return &ReminderService{
+		reminders: reminders,
+		prs:       prs,
+		users:     users,
+		notifier:  notifier,
+		locker:    locker,
+		clock:     clock,
+		after:     after,
+		interval:  interval,
+	}
+}
+
+// SendReminders напоминает PENDING ревьюверам OPEN PR, назначенным дольше after назад.
+// Ревьювер получает не больше одного уведомления в interval со всеми своими
+// такими PR. Недоставленные напоминания не сохраняются и отправятся при следующем запуске
+func (s *ReminderService) SendReminders(ctx context.Context) (domain.ReminderReport, error) {
+	now := s.clock.Now()
+	report := domain.ReminderReport{
+		Sent:   make([]domain.ReviewReminder, 0),
+		Failed: make([]domain.ReminderFailure, 0),
+	}
+
+	stale, err := s.reminders.GetStale(ctx, now.Add(-s.after), now.Add(-s.interval))
+	if err != nil {
+		return domain.ReminderReport{}, err
+	}
+
+	for _, reviews := range groupByReviewer(stale) {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		if err := s.notifier.Notify(ctx, staleNotification(reviews, now)); err != nil {
+			for _, st := range reviews {
+				report.Failed = append(report.Failed, domain.ReminderFailure{
+					PullRequestID: st.PullRequestID,
+					ReviewerID:    st.ReviewerID,
+					Error:         err.Error(),
+				})
+			}
+			continue
+		}
+
+		// история ведется по PR, чтобы ее можно было фильтровать по pull_request_id
+		for _, st := range reviews {
+			rem, err := s.reminders.Create(ctx, domain.ReviewReminder{
+				PullRequestID: st.PullRequestID,
+				ReviewerID:    st.ReviewerID,
+				Channel:       s.notifier.Name(),
+				SentAt:        now,
+			})
+			if err != nil {
+				return report, err
+			}
+			report.Sent = append(report.Sent, rem)
+		}
+	}
+
+	return report, nil
+}
+
+// TriggerReminders — ручной запуск SendReminders под локом задачи ReminderJob,
+// ErrJobRunning, если задача сейчас выполняется в какой-либо реплике
+func (s *ReminderService) TriggerReminders(ctx context.Context) (domain.ReminderReport, error) {
+	unlock, ok, err := s.locker.TryLock(ctx, scheduler.LockKey(ReminderJob))
+	if err != nil {
+		return domain.ReminderReport{}, err
+	}
+	if !ok {
+		return domain.ReminderReport{}, domain.ErrJobRunning
+	}
+	defer unlock()
+
+	return s.SendReminders(ctx)
+}
+
+// stale ревью по ревьюверам в порядке первого появления, внутри — как пришли из репозитория
+func groupByReviewer(stale []domain.StaleReview) [][]domain.StaleReview {
+	idx := make(map[string]int)
+	groups := make([][]domain.StaleReview, 0)
+	for _, st := range stale {
+		i, ok := idx[st.ReviewerID]
+		if !ok {
+			i = len(groups)
+			idx[st.ReviewerID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], st)
+	}
+	return groups
+}
+
+// одно уведомление на ревьювера, reviews отсортированы от самого старого назначения
+func staleNotification(reviews []domain.StaleReview, now time.Time) notify.Notification {
+	oldest := reviews[0]
+	waiting := now.Sub(oldest.AssignedAt).Truncate(time.Minute)
+
+	prs := make([]notify.PullRequestRef, 0, len(reviews))
+	for _, st := range reviews {
+		prs = append(prs, notify.PullRequestRef{ID: st.PullRequestID, Name: st.PullRequestName, URL: st.URL})
+	}
+
+	msg := fmt.Sprintf("pull request %q is waiting for your review for %s", oldest.PullRequestName, waiting)
+	if len(reviews) > 1 {
+		msg = fmt.Sprintf("%d pull requests are waiting for your review, %q for %s",
+			len(reviews), oldest.PullRequestName, waiting)
+	}
+
+	return notify.Notification{
+		Kind:            notify.KindStaleReview,
+		UserID:          oldest.ReviewerID,
+		Username:        oldest.Username,
+		PullRequestID:   oldest.PullRequestID,
+		PullRequestName: oldest.PullRequestName,
+		URL:             oldest.URL,
+		PullRequests:    prs,
+		Message:         msg,
+		CreatedAt:       now,
+	}
+}
+
+// GetReminders — история напоминаний с фильтром по PR и ревьюверу
+func (s *ReminderService) GetReminders(ctx context.Context, prID, reviewerID string) ([]domain.ReviewReminder, error) {
+	if prID != "" {
+		exists, err := s.prs.Exists(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrNotFound
+		}
+	}
+	if reviewerID != "" {
+		if _, err := s.users.GetByID(ctx, reviewerID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.reminders.List(ctx, prID, reviewerID)
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/notify"
+	"pr-reviewer-service/internal/repo"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+// GetStale повторяет условие запроса: назначены не позже before и ревьюверу
+// не напоминали ни о каком PR после after
+type fakeReminders struct {
+	repo.Reminder
+	pending []domain.StaleReview
+	sent    []domain.ReviewReminder
+}
+
+func (f *fakeReminders) GetStale(_ context.Context, assignedBefore, remindedAfter time.Time) ([]domain.StaleReview, error) {
+	res := make([]domain.StaleReview, 0)
+	for _, st := range f.pending {
+		if st.AssignedAt.After(assignedBefore) {
+			continue
+		}
+		reminded := slices.ContainsFunc(f.sent, func(r domain.ReviewReminder) bool {
+			return r.ReviewerID == st.ReviewerID && r.SentAt.After(remindedAfter)
+		})
+		if !reminded {
+			res = append(res, st)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].AssignedAt.Before(res[j].AssignedAt) })
+	return res, nil
+}
+
+func (f *fakeReminders) Create(_ context.Context, r domain.ReviewReminder) (domain.ReviewReminder, error) {
+	r.ID = int64(len(f.sent) + 1)
+	f.sent = append(f.sent, r)
+	return r, nil
+}
+
+type fakeNotifier struct {
+	sent []notify.Notification
+	down map[string]bool // пользователи, которым доставка не проходит
+}
+
+func (n *fakeNotifier) Name() string { return "fake" }
+
+func (n *fakeNotifier) Notify(_ context.Context, msg notify.Notification) error {
+	if n.down[msg.UserID] {
+		return errors.New("delivery failed")
+	}
+	n.sent = append(n.sent, msg)
+	return nil
+}
+
+type fakeJobLocker struct {
+	held map[string]bool
+}
+
+func (l *fakeJobLocker) TryLock(_ context.Context, key string) (func(), bool, error) {
+	if l.held[key] {
+		return nil, false, nil
+	}
+	l.held[key] = true
+	return func() { delete(l.held, key) }, true, nil
+}
+
+func newReminderService(now *time.Time, pending ...domain.StaleReview) (*ReminderService, *fakeReminders, *fakeNotifier, *fakeJobLocker) {
+	reminders := &fakeReminders{pending: pending}
+	notifier := &fakeNotifier{down: map[string]bool{}}
+	locker := &fakeJobLocker{held: map[string]bool{}}
+	clock := ClockFunc(func() time.Time { return *now })
+	svc := NewReminderService(reminders, nil, nil, notifier, locker, clock, 4*time.Hour, 24*time.Hour)
+	return svc, reminders, notifier, locker
+}
+
+func stale(prID, reviewerID string, assignedAt time.Time) domain.StaleReview {
+	return domain.StaleReview{
+		PullRequestID:   prID,
+		PullRequestName: "PR " + prID,
+		ReviewerID:      reviewerID,
+		Username:        reviewerID,
+		AssignedAt:      assignedAt,
+	}
+}
+
+func sentPairs(rems []domain.ReviewReminder) []string {
+	res := make([]string, 0, len(rems))
+	for _, r := range rems {
+		res = append(res, r.PullRequestID+"/"+r.ReviewerID)
+	}
+	return res
+}
+
+func TestSendRemindersGroupsPerReviewer(t *testing.T) {
+	now := monday
+	svc, reminders, notifier, _ := newReminderService(&now,
+		stale("pr-2", "r1", now.Add(-5*time.Hour)),
+		stale("pr-1", "r1", now.Add(-6*time.Hour)),
+		stale("pr-3", "r2", now.Add(-5*time.Hour)),
+		stale("pr-4", "r2", now.Add(-time.Hour)), // назначен недавно
+	)
+
+	report, err := svc.SendReminders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(notifier.sent) != 2 {
+		t.Fatalf("notifications = %+v, want one per reviewer", notifier.sent)
+	}
+	first := notifier.sent[0]
+	if first.UserID != "r1" || first.PullRequestID != "pr-1" || len(first.PullRequests) != 2 ||
+		first.PullRequests[1].ID != "pr-2" || first.Message != `2 pull requests are waiting for your review, "PR pr-1" for 6h0m0s` {
+		t.Errorf("r1 notification = %+v", first)
+	}
+	second := notifier.sent[1]
+	if second.UserID != "r2" || len(second.PullRequests) != 1 ||
+		second.Message != `pull request "PR pr-3" is waiting for your review for 5h0m0s` {
+		t.Errorf("r2 notification = %+v", second)
+	}
+
+	want := []string{"pr-1/r1", "pr-2/r1", "pr-3/r2"}
+	if got := sentPairs(report.Sent); !slices.Equal(got, want) {
+		t.Errorf("report sent = %v, want %v", got, want)
+	}
+	if got := sentPairs(reminders.sent); !slices.Equal(got, want) {
+		t.Errorf("history = %v, want %v", got, want)
+	}
+	if len(report.Failed) != 0 {
+		t.Errorf("report failed = %+v", report.Failed)
+	}
+}
+
+func TestSendRemindersOncePerInterval(t *testing.T) {
+	now := monday
+	svc, reminders, notifier, _ := newReminderService(&now, stale("pr-1", "r1", now.Add(-5*time.Hour)))
+
+	if _, err := svc.SendReminders(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// новый stale PR того же ревьювера ждет конца интервала
+	now = now.Add(12 * time.Hour)
+	reminders.pending = append(reminders.pending, stale("pr-2", "r1", now.Add(-5*time.Hour)))
+	report, err := svc.SendReminders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Sent) != 0 || len(notifier.sent) != 1 {
+		t.Fatalf("reminded again within interval: %+v", report.Sent)
+	}
+
+	now = now.Add(13 * time.Hour)
+	report, err = svc.SendReminders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sentPairs(report.Sent); !slices.Equal(got, []string{"pr-1/r1", "pr-2/r1"}) {
+		t.Errorf("after interval sent = %v", got)
+	}
+	if len(notifier.sent) != 2 {
+		t.Errorf("notifications = %d, want 2", len(notifier.sent))
+	}
+}
+
+func TestSendRemindersFailedAreRetried(t *testing.T) {
+	now := monday
+	svc, reminders, notifier, _ := newReminderService(&now,
+		stale("pr-1", "r1", now.Add(-5*time.Hour)),
+		stale("pr-2", "r1", now.Add(-5*time.Hour)),
+		stale("pr-3", "r2", now.Add(-5*time.Hour)),
+	)
+	notifier.down["r1"] = true
+
+	report, err := svc.SendReminders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Failed) != 2 || report.Failed[0].PullRequestID != "pr-1" || report.Failed[1].PullRequestID != "pr-2" ||
+		report.Failed[0].Error != "delivery failed" {
+		t.Errorf("report failed = %+v, want both PRs of r1", report.Failed)
+	}
+	if got := sentPairs(reminders.sent); !slices.Equal(got, []string{"pr-3/r2"}) {
+		t.Errorf("history = %v, failed reminders must not be saved", got)
+	}
+
+	delete(notifier.down, "r1")
+	now = now.Add(time.Minute)
+	report, err = svc.SendReminders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sentPairs(report.Sent); !slices.Equal(got, []string{"pr-1/r1", "pr-2/r1"}) {
+		t.Errorf("retry sent = %v", got)
+	}
+}
+
+func TestTriggerRemindersTakesJobLock(t *testing.T) {
+	now := monday
+	svc, _, notifier, locker := newReminderService(&now, stale("pr-1", "r1", now.Add(-5*time.Hour)))
+
+	locker.held["job:"+ReminderJob] = true
+	if _, err := svc.TriggerReminders(context.Background()); !errors.Is(err, domain.ErrJobRunning) {
+		t.Fatalf("err = %v, want ErrJobRunning", err)
+	}
+	if len(notifier.sent) != 0 {
+		t.Fatalf("sent while the job was running: %+v", notifier.sent)
+	}
+
+	delete(locker.held, "job:"+ReminderJob)
+	report, err := svc.TriggerReminders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Sent) != 1 {
+		t.Errorf("sent = %+v, want 1", report.Sent)
+	}
+	if len(locker.held) != 0 {
+		t.Errorf("lock not released: %v", locker.held)
+	}
+}
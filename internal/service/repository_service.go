@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/repo"
+)
+
+// репозитории, в которых живут PR: команда-владелец и настройки ревью по умолчанию
+type RepositoryService struct {
+	repos repo.Repository
+	teams repo.Team
+}
+
+func NewRepositoryService(repos repo.Repository, teams repo.Team) *RepositoryService {
+	return &RepositoryService{
+		repos: repos,
+		teams: teams,
+	}
+}
+
+func (s *RepositoryService) Add(ctx context.Context, r domain.Repository) (domain.Repository, error) {
+	if err := r.ReviewPolicy.Validate(); err != nil {
+		return domain.Repository{}, err
+	}
+
+	if r.TeamName != "" {
+		exists, err := s.teams.Exists(ctx, r.TeamName)
+		if err != nil {
+			return domain.Repository{}, err
+		}
+		if !exists {
+			return domain.Repository{}, domain.ErrNotFound
+		}
+	}
+
+	return s.repos.Create(ctx, r)
+}
+
+func (s *RepositoryService) Get(ctx context.Context, name string) (domain.Repository, error) {
+	return s.repos.GetByName(ctx, name)
+}
+
+func (s *RepositoryService) List(ctx context.Context) ([]domain.Repository, error) {
+	return s.repos.List(ctx)
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"pr-reviewer-service/internal/domain"
+	"slices"
+	"time"
+)
+
+// ReviewAges считает для PENDING ревьюверов OPEN PR, сколько рабочих часов прошло
+// с назначения. Учитываются только команды с заданным SLA; teamName = "" - все команды,
+// all = false - только просроченные
+func (s *PRService) ReviewAges(ctx context.Context, teamName string, all bool) ([]domain.ReviewAge, error) {
+	if teamName != "" {
+		exists, err := s.teams.Exists(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrNotFound
+		}
+	}
+
+	ages, err := s.reviewAges(ctx, teamName, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if all {
+		return ages, nil
+	}
+
+	overdue := make([]domain.ReviewAge, 0, len(ages))
+	for _, a := range ages {
+		if a.Overdue {
+			overdue = append(overdue, a)
+		}
+	}
+	return overdue, nil
+}
+
+func (s *PRService) reviewAges(ctx context.Context, teamName string, now time.Time) ([]domain.ReviewAge, error) {
+	pending, err := s.prs.GetPendingReviews(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	slas := make(map[string]domain.ReviewSLA)
+	ids := make([]string, 0, len(pending))
+	for _, p := range pending {
+		if _, ok := slas[p.TeamName]; !ok {
+			sla, err := s.teams.GetReviewSLA(ctx, p.TeamName)
+			if err != nil {
+				return nil, err
+			}
+			slas[p.TeamName] = sla
+		}
+		ids = append(ids, p.ReviewerID)
+	}
+
+	users, err := s.users.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	reviewers := make(map[string]domain.User, len(users))
+	for _, u := range users {
+		reviewers[u.UserID] = u
+	}
+
+	ages := make([]domain.ReviewAge, 0, len(pending))
+	for _, p := range pending {
+		sla := slas[p.TeamName]
+		if sla.ResponseHours == 0 {
+			continue
+		}
+
+		// рабочее время ревьювера; если его нет в users, считаем по UTC без окна
+		age := reviewers[p.ReviewerID].BusinessTimeBetween(p.AssignedAt, now).Hours()
+		ages = append(ages, domain.ReviewAge{
+			PullRequestID:   p.PullRequestID,
+			ReviewerID:      p.ReviewerID,
+			TeamName:        p.TeamName,
+			AssignedAt:      p.AssignedAt,
+			AgeHours:        math.Round(age*100) / 100,
+			ResponseHours:   sla.ResponseHours,
+			EscalationHours: sla.EscalationHours,
+			Overdue:         age >= float64(sla.ResponseHours),
+			Escalated:       p.Escalated,
+		})
+	}
+	return ages, nil
+}
+
+// EscalateOverdue применяет правило эскалации команды к назначениям, которые
+// превысили escalation_hours и еще не эскалировались. REASSIGN идет тем же путем,
+// что /pullRequest/reassign; если замены нет, назначение пропускается до следующего запуска
+func (s *PRService) EscalateOverdue(ctx context.Context) ([]domain.ReviewEscalation, error) {
+	escalations := make([]domain.ReviewEscalation, 0)
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.tx.Lock(ctx, assignmentLockKey); err != nil {
+			return err
+		}
+
+		now := s.clock.Now()
+		ages, err := s.reviewAges(ctx, "", now)
+		if err != nil {
+			return err
+		}
+
+		slas := make(map[string]domain.ReviewSLA)
+		for _, a := range ages {
+			if a.Escalated || a.EscalationHours == 0 || a.AgeHours < float64(a.EscalationHours) {
+				continue
+			}
+			sla, ok := slas[a.TeamName]
+			if !ok {
+				if sla, err = s.teams.GetReviewSLA(ctx, a.TeamName); err != nil {
+					return err
+				}
+				slas[a.TeamName] = sla
+			}
+
+			e := domain.ReviewEscalation{
+				PullRequestID: a.PullRequestID,
+				ReviewerID:    a.ReviewerID,
+				AssignedAt:    a.AssignedAt,
+				Action:        sla.EscalationAction,
+				CreatedAt:     now,
+			}
+
+			switch sla.EscalationAction {
+			case domain.EscalationActionReassign:
+				_, newReviewerID, err := s.reassignReviewer(ctx, a.PullRequestID, a.ReviewerID, "", domain.PREventReasonEscalation)
+				if errors.Is(err, domain.ErrNoCandidate) || errors.Is(err, domain.ErrNotFound) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				e.EscalatedTo = newReviewerID
+			case domain.EscalationActionAddUser:
+				code, err := s.addEscalationReviewer(ctx, a.PullRequestID, sla.EscalationUserID, now)
+				if err != nil {
+					return err
+				}
+				if code != "" {
+					// пользователь не подходит: эскалация записывается как не примененная
+					e.Error = code
+					break
+				}
+				e.EscalatedTo = sla.EscalationUserID
+			default:
+				continue
+			}
+
+			if err := s.prs.AddEscalation(ctx, e); err != nil {
+				return err
+			}
+			escalations = append(escalations, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return escalations, nil
+}
+
+// добавляет пользователя для эскалации PENDING ревьювером с теми же проверками, что
+// при ручном назначении: пользователь существует, активен, не отсутствует, не автор
+// и не в паре исключений с автором, а у PR есть место до max_reviewers. Возвращает код,
+// почему эскалация не применена; уже назначенный пользователь считается эскалацией.
+// Лимит открытых ревью и рабочее время не проверяются
+func (s *PRService) addEscalationReviewer(ctx context.Context, prID, userID string, now time.Time) (domain.ErrorCode, error) {
+	if userID == "" {
+		return domain.ErrorReviewerNotFound, nil
+	}
+	pr, err := s.prs.GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		return "", err
+	}
+	if pr.AuthorID == userID {
+		return domain.ErrorReviewerIsAuthor, nil
+	}
+	if pr.HasReviewer(userID) {
+		return "", nil
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrorReviewerNotFound, nil
+		}
+		return "", err
+	}
+	if !user.IsActive {
+		return domain.ErrorReviewerInactive, nil
+	}
+	available, err := s.users.GetAvailableByIDs(ctx, []string{userID}, now)
+	if err != nil {
+		return "", err
+	}
+	if len(available) == 0 {
+		return domain.ErrorReviewerUnavailable, nil
+	}
+	conflict, err := s.inConflict(ctx, pr.AuthorID, userID)
+	if err != nil {
+		return "", err
+	}
+	if conflict {
+		return domain.ErrorConflictOfInterest, nil
+	}
+
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return "", err
+	}
+	policy, err := s.reviewPolicyFor(ctx, pr, author.TeamName)
+	if err != nil {
+		return "", err
+	}
+	if len(pr.Reviewers) >= policy.MaxReviewers {
+		return domain.ErrorTooManyReviewers, nil
+	}
+
+	before := pr.Reviewers
+	pr.Reviewers = append(slices.Clone(pr.Reviewers), domain.NewReviewers([]string{userID}, now)...)
+	if user.TeamName != author.TeamName {
+		pr.FallbackReviewers = append(pr.FallbackReviewers, userID)
+	}
+	if err := s.prs.SetReviewers(ctx, pr); err != nil {
+		return "", err
+	}
+	if err := s.prs.AddEvents(ctx, domain.ReviewerChangeEvents(prID, before, pr.Reviewers, domain.PREventReasonEscalation, now)); err != nil {
+		return "", err
+	}
+
+	return "", s.prs.AddAssignmentExplanation(ctx, domain.AssignmentExplanation{
+		PullRequestID: prID,
+		Action:        domain.AssignmentActionEscalate,
+		CreatedAt:     now,
+		Candidates: []domain.CandidateExplanation{{
+			UserID:   userID,
+			TeamName: user.TeamName,
+			Picked:   true,
+			Reason:   domain.CandidateReasonRequested,
+			Detail:   "review sla escalation",
+		}},
+	})
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-service/internal/domain"
+	"slices"
+	"testing"
+	"time"
+)
+
+// понедельник, у пользователей без рабочего окна рабочие сутки целиком
+var monday = time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC)
+
+func newEscalationStore(action domain.EscalationAction) *fakeStore {
+	st := newFakeStore(monday)
+	policy := domain.DefaultReviewPolicy()
+	policy.ReviewerStrategy = domain.ReviewerStrategyFirstN
+	st.addTeam("backend", policy,
+		domain.User{UserID: "author"}, domain.User{UserID: "r1"}, domain.User{UserID: "r2"})
+	st.addTeam("leads", domain.DefaultReviewPolicy(), domain.User{UserID: "lead"})
+	st.teams["backend"].sla = domain.ReviewSLA{
+		ResponseHours:    4,
+		EscalationHours:  8,
+		EscalationAction: action,
+		EscalationUserID: "lead",
+	}
+	st.addPR("pr-1", "author", "r1")
+	return st
+}
+
+func TestEscalateOverdue(t *testing.T) {
+	tests := []struct {
+		name      string
+		action    domain.EscalationAction
+		after     time.Duration
+		setup     func(st *fakeStore)
+		want      []domain.ReviewEscalation
+		reviewers []string
+	}{
+		{
+			name:      "not due yet",
+			action:    domain.EscalationActionAddUser,
+			after:     7 * time.Hour,
+			reviewers: []string{"r1"},
+		},
+		{
+			name:      "add user",
+			action:    domain.EscalationActionAddUser,
+			after:     9 * time.Hour,
+			want:      []domain.ReviewEscalation{{ReviewerID: "r1", Action: domain.EscalationActionAddUser, EscalatedTo: "lead"}},
+			reviewers: []string{"r1", "lead"},
+		},
+		{
+			name:      "reassign",
+			action:    domain.EscalationActionReassign,
+			after:     9 * time.Hour,
+			want:      []domain.ReviewEscalation{{ReviewerID: "r1", Action: domain.EscalationActionReassign, EscalatedTo: "r2"}},
+			reviewers: []string{"r2"},
+		},
+		{
+			name:   "reassign without candidates waits",
+			action: domain.EscalationActionReassign,
+			after:  9 * time.Hour,
+			setup: func(st *fakeStore) {
+				r2 := st.users["r2"]
+				r2.IsActive = false
+				st.users["r2"] = r2
+			},
+			reviewers: []string{"r1"},
+		},
+		{
+			name:   "add user in conflict with author",
+			action: domain.EscalationActionAddUser,
+			after:  9 * time.Hour,
+			setup: func(st *fakeStore) {
+				st.exclusions = append(st.exclusions, [2]string{"author", "lead"})
+			},
+			want:      []domain.ReviewEscalation{{ReviewerID: "r1", Action: domain.EscalationActionAddUser, Error: domain.ErrorConflictOfInterest}},
+			reviewers: []string{"r1"},
+		},
+		{
+			name:   "add user out of office",
+			action: domain.EscalationActionAddUser,
+			after:  9 * time.Hour,
+			setup: func(st *fakeStore) {
+				st.away = append(st.away, domain.Unavailability{UserID: "lead", StartsAt: monday, EndsAt: monday.AddDate(0, 0, 7)})
+			},
+			want:      []domain.ReviewEscalation{{ReviewerID: "r1", Action: domain.EscalationActionAddUser, Error: domain.ErrorReviewerUnavailable}},
+			reviewers: []string{"r1"},
+		},
+		{
+			name:   "add user inactive",
+			action: domain.EscalationActionAddUser,
+			after:  9 * time.Hour,
+			setup: func(st *fakeStore) {
+				lead := st.users["lead"]
+				lead.IsActive = false
+				st.users["lead"] = lead
+			},
+			want:      []domain.ReviewEscalation{{ReviewerID: "r1", Action: domain.EscalationActionAddUser, Error: domain.ErrorReviewerInactive}},
+			reviewers: []string{"r1"},
+		},
+		{
+			name:   "add user over max reviewers",
+			action: domain.EscalationActionAddUser,
+			after:  9 * time.Hour,
+			setup: func(st *fakeStore) {
+				st.teams["backend"].policy.MaxReviewers = 1
+			},
+			want:      []domain.ReviewEscalation{{ReviewerID: "r1", Action: domain.EscalationActionAddUser, Error: domain.ErrorTooManyReviewers}},
+			reviewers: []string{"r1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newEscalationStore(tt.action)
+			if tt.setup != nil {
+				tt.setup(st)
+			}
+			st.now = st.now.Add(tt.after)
+			svc := st.service()
+
+			got, err := svc.EscalateOverdue(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("escalations = %+v, want %+v", got, tt.want)
+			}
+			for i, e := range got {
+				w := tt.want[i]
+				if e.PullRequestID != "pr-1" || e.ReviewerID != w.ReviewerID || e.Action != w.Action ||
+					e.EscalatedTo != w.EscalatedTo || e.Error != w.Error {
+					t.Errorf("escalation = %+v, want %+v", e, w)
+				}
+			}
+			if reviewers := st.pr("pr-1").ReviewerIDs(); !slices.Equal(reviewers, tt.reviewers) {
+				t.Errorf("reviewers = %v, want %v", reviewers, tt.reviewers)
+			}
+
+			// записанная эскалация, в том числе не примененная, не повторяется
+			again, err := svc.EscalateOverdue(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tt.want) > 0 && len(again) != 0 {
+				t.Errorf("second run escalated again: %+v", again)
+			}
+		})
+	}
+}
+
+func TestEscalateOverdueAddedUserIsFallback(t *testing.T) {
+	st := newEscalationStore(domain.EscalationActionAddUser)
+	st.now = st.now.Add(9 * time.Hour)
+
+	if _, err := st.service().EscalateOverdue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	pr := st.pr("pr-1")
+	if !slices.Equal(pr.FallbackReviewers, []string{"lead"}) {
+		t.Errorf("fallback reviewers = %v, want [lead]", pr.FallbackReviewers)
+	}
+	if len(st.explanations) != 1 || st.explanations[0].Action != domain.AssignmentActionEscalate {
+		t.Errorf("explanations = %+v", st.explanations)
+	}
+}
+
+func TestReviewAgesUseBusinessTime(t *testing.T) {
+	// назначение в пятницу вечером, выходные не считаются
+	friday := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	st := newFakeStore(friday)
+	st.addTeam("backend", domain.DefaultReviewPolicy(), domain.User{UserID: "author"}, domain.User{UserID: "r1"})
+	st.teams["backend"].sla = domain.ReviewSLA{ResponseHours: 8}
+	st.addPR("pr-1", "author", "r1")
+	st.now = time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+
+	ages, err := st.service().ReviewAges(context.Background(), "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ages) != 1 || ages[0].AgeHours != 7 || ages[0].Overdue {
+		t.Fatalf("ages = %+v, want 7h, not overdue", ages)
+	}
+
+	st.now = st.now.Add(time.Hour)
+	overdue, err := st.service().ReviewAges(context.Background(), "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overdue) != 1 || !overdue[0].Overdue {
+		t.Errorf("overdue = %+v, want the review after 8 business hours", overdue)
+	}
+}
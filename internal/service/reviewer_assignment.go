@@ -0,0 +1,483 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"pr-reviewer-service/internal/domain"
+	"sort"
+	"strings"
+	"time"
+)
+
+// параметры подбора ревьюверов из команд
+type poolPick struct {
+	teams   []string            // команды в порядке приоритета
+	primary map[string]struct{} // выбранные не из этих команд считаются запасными (fallback)
+	exclude map[string]struct{} // автор и уже назначенные
+	labels  []string            // метки PR: кандидаты с подходящими тегами идут первыми
+	matched bool                // среди уже выбранных вне пулов есть ревьювер с подходящим тегом
+	n       int
+	recent  map[string]int   // сколько раз кандидат ревьюил последние PR автора, такие выбираются позже
+	now     time.Time        // по нему проверяются отсутствие и рабочее время
+	trace   *assignmentTrace // сюда пишется, почему кандидат выбран или пропущен
+}
+
+type teamPool struct {
+	team     string
+	strategy domain.ReviewerStrategy
+	users    []domain.User
+}
+
+type poolResult struct {
+	picked    []string
+	fallback  []string // выбранные не из primary команд
+	saturated int      // активных кандидатов пропущено из-за лимита max_open_reviews
+}
+
+// набирает до n ревьюверов, проходя команды по порядку, пока не наберется нужное число.
+// Кандидаты, достигшие лимита OPEN PR на ревью, пропускаются, среди остальных
+// выбирает pickFromTiers
+func (s *PRService) pickFromPools(ctx context.Context, p poolPick) (poolResult, error) {
+	var res poolResult
+
+	skip := make(map[string]struct{}, len(p.exclude))
+	for id := range p.exclude {
+		skip[id] = struct{}{}
+	}
+
+	pools := make([]teamPool, 0, len(p.teams))
+	visited := make(map[string]struct{}, len(p.teams))
+	for _, team := range p.teams {
+		if _, ok := visited[team]; ok {
+			continue
+		}
+		visited[team] = struct{}{}
+
+		policy, err := s.teams.GetReviewPolicy(ctx, team)
+		if err != nil {
+			return poolResult{}, err
+		}
+		users, err := s.users.GetActiveByTeam(ctx, team, p.now)
+		if err != nil {
+			return poolResult{}, err
+		}
+		if err := s.traceUnavailable(ctx, p, team, users, skip); err != nil {
+			return poolResult{}, err
+		}
+
+		available, saturated, err := s.splitByCapacity(ctx, users, skip)
+		if err != nil {
+			return poolResult{}, err
+		}
+		res.saturated += len(saturated)
+		for _, u := range saturated {
+			p.trace.skip(u.UserID, team, domain.CandidateReasonAtCapacity, "max_open_reviews reached")
+		}
+		pools = append(pools, teamPool{team: team, strategy: policy.ReviewerStrategy, users: available})
+	}
+
+	picked, fallback, err := pickFromTiers(ctx, s.selectors, tierPick{
+		pools:   pools,
+		primary: p.primary,
+		skip:    skip,
+		labels:  tagSet(p.labels),
+		matched: p.matched,
+		n:       p.n,
+		recent:  p.recent,
+		now:     p.now,
+		trace:   p.trace,
+	})
+	if err != nil {
+		return poolResult{}, err
+	}
+	res.picked, res.fallback = picked, fallback
+
+	return res, nil
+}
+
+// выбор из уже прочитанных пулов без обращений к БД, кроме селекторов стратегий.
+// Общий для create, reassign и массовой деактивации
+type tierPick struct {
+	pools   []teamPool          // доступные кандидаты команд в порядке приоритета
+	primary map[string]struct{} // выбранные не из этих команд считаются запасными (fallback)
+	skip    map[string]struct{} // исключенные, дополняется выбранными
+	labels  map[string]struct{} // метки PR
+	matched bool                // среди уже назначенных есть ревьювер с подходящим тегом
+	n       int
+	recent  map[string]int
+	now     time.Time
+	trace   *assignmentTrace
+}
+
+// набирает до n ревьюверов, проходя пулы по порядку. Внутри пула сначала кандидаты
+// с тегами из меток PR, затем остальные; внутри группы раньше выбираются те, у кого
+// сейчас рабочее время, а среди них — те, кто реже ревьюил последние PR автора,
+// в каждой подгруппе стратегией команды. Если среди выбранных и уже назначенных нет
+// ревьювера с подходящим тегом, а в пулах есть — он заменяет последнего выбранного
+func pickFromTiers(ctx context.Context, selectors map[domain.ReviewerStrategy]ReviewerSelector, p tierPick) (picked, fallback []string, err error) {
+	picked = make([]string, 0, p.n)
+	pickedTeam := make(map[string]string, p.n)
+	matched := p.matched
+	add := func(pool teamPool, ids []string, matching bool, reason domain.CandidateReason) {
+		for _, id := range ids {
+			p.skip[id] = struct{}{}
+			picked = append(picked, id)
+			pickedTeam[id] = pool.team
+			matched = matched || matching
+
+			_, primary := p.primary[pool.team]
+			detail := pickDetail(pool.strategy, matching, !primary)
+			if cnt := p.recent[id]; cnt > 0 {
+				detail += fmt.Sprintf(", reviewed %d of author's recent PRs", cnt)
+			}
+			p.trace.pick(id, pool.team, reason, detail)
+		}
+	}
+
+	for _, pool := range p.pools {
+		matching, rest := splitByTags(pool.users, p.labels)
+
+		for _, group := range []struct {
+			users    []domain.User
+			matching bool
+		}{{matching, true}, {rest, false}} {
+			if len(picked) >= p.n {
+				break
+			}
+
+			ids, err := selectInTiers(ctx, selectors, pool, group.users, p.n-len(picked), p)
+			if err != nil {
+				return nil, nil, err
+			}
+			add(pool, ids, group.matching, domain.CandidateReasonSelected)
+		}
+	}
+
+	if len(p.labels) > 0 && p.n > 0 && !matched {
+		for _, pool := range p.pools {
+			matching, _ := splitByTags(pool.users, p.labels)
+
+			ids, err := selectInTiers(ctx, selectors, pool, matching, 1, p)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(ids) == 0 {
+				continue
+			}
+
+			if len(picked) >= p.n {
+				last := picked[len(picked)-1]
+				picked = picked[:len(picked)-1]
+				delete(pickedTeam, last)
+				p.trace.unpick(last, domain.CandidateReasonNotSelected, "replaced by a reviewer matching PR labels")
+			}
+			add(pool, ids, true, domain.CandidateReasonLabelMatch)
+			break
+		}
+	}
+
+	for _, pool := range p.pools {
+		for _, u := range pool.users {
+			details := make([]string, 0, 2)
+			if u.UntilWorkingHours(p.now) > 0 {
+				details = append(details, "outside working hours")
+			}
+			if cnt := p.recent[u.UserID]; cnt > 0 {
+				details = append(details, fmt.Sprintf("reviewed %d of author's recent PRs", cnt))
+			}
+			p.trace.skip(u.UserID, pool.team, domain.CandidateReasonNotSelected, strings.Join(details, ", "))
+		}
+	}
+
+	for _, id := range picked {
+		if _, ok := p.primary[pickedTeam[id]]; !ok {
+			fallback = append(fallback, id)
+		}
+	}
+	return picked, fallback, nil
+}
+
+// записывает в объяснение участников команды, которых нет среди доступных:
+// неактивных, отсутствующих, а также исключенных (автор, уже назначенные)
+func (s *PRService) traceUnavailable(ctx context.Context, p poolPick, team string, available []domain.User, skip map[string]struct{}) error {
+	members, err := s.users.GetByTeam(ctx, team)
+	if err != nil {
+		return err
+	}
+
+	availableIDs := make(map[string]struct{}, len(available))
+	for _, u := range available {
+		availableIDs[u.UserID] = struct{}{}
+	}
+
+	for _, m := range members {
+		if _, ok := skip[m.UserID]; ok {
+			p.trace.skip(m.UserID, team, domain.CandidateReasonAlreadyAssigned, "")
+			continue
+		}
+		if !m.IsActive {
+			p.trace.skip(m.UserID, team, domain.CandidateReasonInactive, "")
+			continue
+		}
+		if _, ok := availableIDs[m.UserID]; !ok {
+			p.trace.skip(m.UserID, team, domain.CandidateReasonOutOfOffice, "")
+		}
+	}
+	return nil
+}
+
+// пользователи, явно указанные владельцами путей, идут первыми: доступные сейчас,
+// сначала в рабочее время, затем наименее загруженные
+func (s *PRService) pickOwnerUsers(ctx context.Context, ownerIDs []string, p poolPick, n int) ([]domain.User, error) {
+	if len(ownerIDs) == 0 {
+		return []domain.User{}, nil
+	}
+
+	available, err := s.users.GetAvailableByIDs(ctx, ownerIDs, p.now)
+	if err != nil {
+		return nil, err
+	}
+
+	availableIDs := make(map[string]struct{}, len(available))
+	for _, u := range available {
+		availableIDs[u.UserID] = struct{}{}
+	}
+	for _, id := range ownerIDs {
+		if _, ok := availableIDs[id]; !ok {
+			p.trace.skip(id, "", domain.CandidateReasonUnavailable, "inactive, out of office or unknown")
+		}
+	}
+
+	candidates, saturated, err := s.splitByCapacity(ctx, available, p.exclude)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range saturated {
+		p.trace.skip(u.UserID, u.TeamName, domain.CandidateReasonAtCapacity, "max_open_reviews reached")
+	}
+
+	byID := make(map[string]domain.User, len(candidates))
+	for _, u := range candidates {
+		byID[u.UserID] = u
+	}
+	if len(candidates) == 0 || n <= 0 {
+		return []domain.User{}, nil
+	}
+
+	picked := make([]domain.User, 0, n)
+	for _, tier := range byWorkingHours(candidates, p.now) {
+		if len(picked) >= n {
+			break
+		}
+
+		ids, err := s.selectors[domain.ReviewerStrategyLeastLoaded].Select(ctx, "", tier, n-len(picked))
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			picked = append(picked, byID[id])
+			detail := "least loaded owner"
+			if _, ok := p.primary[byID[id].TeamName]; !ok {
+				detail += ", fallback team"
+			}
+			p.trace.pick(id, byID[id].TeamName, domain.CandidateReasonCodeOwner, detail)
+		}
+	}
+	for _, u := range candidates {
+		p.trace.skip(u.UserID, u.TeamName, domain.CandidateReasonNotSelected, "less loaded owners preferred")
+	}
+	return picked, nil
+}
+
+// пояснение к выбору: стратегия команды, совпадение тегов, запасная команда
+func pickDetail(strategy domain.ReviewerStrategy, matching, fallback bool) string {
+	parts := []string{"strategy " + string(strategy)}
+	if matching {
+		parts = append(parts, "tags match PR labels")
+	}
+	if fallback {
+		parts = append(parts, "fallback team")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// выбирает до n ревьюверов стратегией команды, проходя кандидатов группами по близости
+// рабочего времени: сначала работающие сейчас, затем те, у кого рабочий день начнется раньше.
+// Внутри группы сначала идут те, кто реже ревьюил последние PR автора
+func selectInTiers(ctx context.Context, selectors map[domain.ReviewerStrategy]ReviewerSelector, pool teamPool, candidates []domain.User, n int, p tierPick) ([]string, error) {
+	picked := make([]string, 0, n)
+	for _, hoursTier := range byWorkingHours(candidates, p.now) {
+		for _, tier := range byRecentPairs(hoursTier, p.recent) {
+			if len(picked) >= n {
+				return picked, nil
+			}
+
+			filtered := make([]domain.User, 0, len(tier))
+			for _, u := range tier {
+				if _, skip := p.skip[u.UserID]; !skip {
+					filtered = append(filtered, u)
+				}
+			}
+			if len(filtered) == 0 {
+				continue
+			}
+
+			ids, err := selectByStrategy(ctx, selectors, pool.strategy, pool.team, filtered, n-len(picked))
+			if err != nil {
+				return nil, err
+			}
+			picked = append(picked, ids...)
+		}
+	}
+	return picked, nil
+}
+
+// выбирает до n ревьюверов селектором стратегии, неизвестная стратегия заменяется стратегией по умолчанию
+func selectByStrategy(ctx context.Context, selectors map[domain.ReviewerStrategy]ReviewerSelector, strategy domain.ReviewerStrategy, teamName string, candidates []domain.User, n int) ([]string, error) {
+	selector, ok := selectors[strategy]
+	if !ok {
+		selector = selectors[domain.DefaultReviewPolicy().ReviewerStrategy]
+	}
+	return selector.Select(ctx, teamName, candidates, n)
+}
+
+// делит кандидатов на тех, у кого есть запас по лимиту OPEN PR на ревью, и достигших лимита.
+// Лимит — max_open_reviews пользователя, иначе default_max_open_reviews его команды.
+// Исключенные (автор, уже назначенные) в результат не попадают
+func (s *PRService) splitByCapacity(ctx context.Context, users []domain.User, exclude map[string]struct{}) (available, saturated []domain.User, err error) {
+	candidates := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		if _, skip := exclude[u.UserID]; !skip {
+			candidates = append(candidates, u)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil, nil
+	}
+
+	load, err := s.prs.GetOpenReviewLoad(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	teamLimits := make(map[string]*int)
+	for _, u := range candidates {
+		if _, ok := teamLimits[u.TeamName]; ok || u.MaxOpenReviews != nil {
+			continue
+		}
+		policy, err := s.teams.GetReviewPolicy(ctx, u.TeamName)
+		if err != nil {
+			return nil, nil, err
+		}
+		teamLimits[u.TeamName] = policy.DefaultMaxOpenReviews
+	}
+
+	available, saturated = splitByLoad(candidates, nil, load, teamLimits)
+	return available, saturated, nil
+}
+
+// splitByCapacity без обращений к БД: нагрузка и default_max_open_reviews команд уже известны
+func splitByLoad(users []domain.User, exclude map[string]struct{}, load map[string]int, teamLimits map[string]*int) (available, saturated []domain.User) {
+	for _, u := range users {
+		if _, skip := exclude[u.UserID]; skip {
+			continue
+		}
+		limit := u.MaxOpenReviews
+		if limit == nil {
+			limit = teamLimits[u.TeamName]
+		}
+
+		if limit != nil && load[u.UserID] >= *limit {
+			saturated = append(saturated, u)
+			continue
+		}
+		available = append(available, u)
+	}
+	return available, saturated
+}
+
+// группирует пользователей по времени до начала рабочего окна, по возрастанию
+func byWorkingHours(users []domain.User, now time.Time) [][]domain.User {
+	groups := make(map[time.Duration][]domain.User)
+	waits := make([]time.Duration, 0)
+	for _, u := range users {
+		wait := u.UntilWorkingHours(now)
+		if _, ok := groups[wait]; !ok {
+			waits = append(waits, wait)
+		}
+		groups[wait] = append(groups[wait], u)
+	}
+	sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+
+	tiers := make([][]domain.User, 0, len(waits))
+	for _, w := range waits {
+		tiers = append(tiers, groups[w])
+	}
+	return tiers
+}
+
+// группирует пользователей по числу недавних ревью PR автора, по возрастанию
+func byRecentPairs(users []domain.User, recent map[string]int) [][]domain.User {
+	if len(recent) == 0 {
+		return [][]domain.User{users}
+	}
+
+	groups := make(map[int][]domain.User)
+	counts := make([]int, 0)
+	for _, u := range users {
+		cnt := recent[u.UserID]
+		if _, ok := groups[cnt]; !ok {
+			counts = append(counts, cnt)
+		}
+		groups[cnt] = append(groups[cnt], u)
+	}
+	sort.Ints(counts)
+
+	tiers := make([][]domain.User, 0, len(counts))
+	for _, c := range counts {
+		tiers = append(tiers, groups[c])
+	}
+	return tiers
+}
+
+func tagSet(tags []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		set[t] = struct{}{}
+	}
+	return set
+}
+
+func hasTag(u domain.User, labels map[string]struct{}) bool {
+	for _, t := range u.Tags {
+		if _, ok := labels[t]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// делит кандидатов на тех, у кого есть тег из меток, и остальных
+func splitByTags(users []domain.User, labels map[string]struct{}) (matching, rest []domain.User) {
+	if len(labels) == 0 {
+		return nil, users
+	}
+	for _, u := range users {
+		if hasTag(u, labels) {
+			matching = append(matching, u)
+		} else {
+			rest = append(rest, u)
+		}
+	}
+	return matching, rest
+}
+
+func removeID(ids []string, target string) []string {
+	res := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != target {
+			res = append(res, id)
+		}
+	}
+	return res
+}
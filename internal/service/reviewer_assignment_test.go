@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-service/internal/domain"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// подбор из команды backend для PR автора из команды author
+func pickBackend(t *testing.T, st *fakeStore, n int, labels ...string) []string {
+	t.Helper()
+	trace := newAssignmentTrace()
+	res, err := st.service().pickFromPools(context.Background(), poolPick{
+		teams:   []string{"backend"},
+		primary: map[string]struct{}{"backend": {}},
+		exclude: map[string]struct{}{"author": {}},
+		labels:  labels,
+		n:       n,
+		now:     st.now,
+		trace:   trace,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.picked
+}
+
+func TestPickFromPoolsWorkingHours(t *testing.T) {
+	morning := func(id string) domain.User {
+		start, end := "09:00", "18:00"
+		return domain.User{UserID: id, TimeZone: "UTC", WorkStart: &start, WorkEnd: &end}
+	}
+	evening := func(id string) domain.User {
+		start, end := "13:00", "22:00"
+		return domain.User{UserID: id, TimeZone: "UTC", WorkStart: &start, WorkEnd: &end}
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		n    int
+		want []string
+	}{
+		{"both working, strategy order", monday.Add(4 * time.Hour), 1, []string{"u1"}},
+		{"only evening shift working", monday.Add(9 * time.Hour), 1, []string{"u2"}},
+		{"off hours fill the rest", monday.Add(9 * time.Hour), 2, []string{"u2", "u1"}},
+		{"nobody working, earliest start first", monday.Add(13 * time.Hour), 2, []string{"u1", "u2"}},
+		// в субботу 20:00 вечерняя смена не работает, оба ждут понедельника
+		{"weekend, earliest start on monday first", time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC), 1, []string{"u1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newFakeStore(tt.now)
+			policy := domain.DefaultReviewPolicy()
+			policy.ReviewerStrategy = domain.ReviewerStrategyFirstN
+			st.addTeam("backend", policy, morning("u1"), evening("u2"))
+
+			if got := pickBackend(t, st, tt.n); !slices.Equal(got, tt.want) {
+				t.Errorf("picked %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickFromPoolsLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		platform   []string // теги p1 из запасной команды platform
+		tagged     string   // участник backend с тегом db
+		matched    bool
+		n          int
+		want       []string
+		labelPick  string                 // выбранный кандидат с тегом
+		wantReason domain.CandidateReason // и причина его выбора
+	}{
+		{
+			name:       "matching member of the team first",
+			tagged:     "u3",
+			n:          2,
+			want:       []string{"u3", "u1"},
+			labelPick:  "u3",
+			wantReason: domain.CandidateReasonSelected,
+		},
+		{
+			name:       "matching fallback replaces the last pick",
+			platform:   []string{"db"},
+			n:          2,
+			want:       []string{"u1", "p1"},
+			labelPick:  "p1",
+			wantReason: domain.CandidateReasonLabelMatch,
+		},
+		{
+			name:     "already matched by another reviewer",
+			platform: []string{"db"},
+			matched:  true,
+			n:        2,
+			want:     []string{"u1", "u2"},
+		},
+		{
+			name: "nobody matches",
+			n:    2,
+			want: []string{"u1", "u2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newFakeStore(monday)
+			policy := domain.DefaultReviewPolicy()
+			policy.ReviewerStrategy = domain.ReviewerStrategyFirstN
+			st.addTeam("backend", policy, domain.User{UserID: "author"},
+				domain.User{UserID: "u1"}, domain.User{UserID: "u2"}, domain.User{UserID: "u3"})
+			st.addTeam("platform", policy, domain.User{UserID: "p1", Tags: tt.platform})
+			if tt.tagged != "" {
+				u := st.users[tt.tagged]
+				u.Tags = []string{"db"}
+				st.users[tt.tagged] = u
+			}
+
+			trace := newAssignmentTrace()
+			res, err := st.service().pickFromPools(context.Background(), poolPick{
+				teams:   []string{"backend", "platform"},
+				primary: map[string]struct{}{"backend": {}},
+				exclude: map[string]struct{}{"author": {}},
+				labels:  []string{"db"},
+				matched: tt.matched,
+				n:       tt.n,
+				now:     st.now,
+				trace:   trace,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(res.picked, tt.want) {
+				t.Fatalf("picked %v, want %v", res.picked, tt.want)
+			}
+
+			if tt.wantReason != "" {
+				c := trace.candidates[trace.index[tt.labelPick]]
+				if !c.Picked || c.Reason != tt.wantReason {
+					t.Errorf("candidate %s = %+v, want picked with reason %s", tt.labelPick, c, tt.wantReason)
+				}
+			}
+			// вытесненный кандидат остается в объяснении с причиной
+			if tt.wantReason == domain.CandidateReasonLabelMatch {
+				c := trace.candidates[trace.index["u2"]]
+				if c.Picked || c.Detail != "replaced by a reviewer matching PR labels" {
+					t.Errorf("replaced candidate u2 = %+v", c)
+				}
+			}
+		})
+	}
+}
+
+func TestPickFromPoolsCapacity(t *testing.T) {
+	one, three := 1, 3
+
+	tests := []struct {
+		name      string
+		teamLimit *int
+		u1Limit   *int
+		want      []string
+	}{
+		{name: "no limits", want: []string{"u1", "u2"}},
+		{name: "team limit reached", teamLimit: &one, want: []string{"u2"}},
+		{name: "own limit above team limit", teamLimit: &one, u1Limit: &three, want: []string{"u1", "u2"}},
+		{name: "own limit without team limit", u1Limit: &one, want: []string{"u2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newFakeStore(monday)
+			policy := domain.DefaultReviewPolicy()
+			policy.ReviewerStrategy = domain.ReviewerStrategyFirstN
+			policy.DefaultMaxOpenReviews = tt.teamLimit
+			st.addTeam("backend", policy, domain.User{UserID: "author"},
+				domain.User{UserID: "u1", MaxOpenReviews: tt.u1Limit}, domain.User{UserID: "u2"})
+			// у u1 уже одно открытое ревью
+			st.addPR("other", "u2", "u1")
+
+			trace := newAssignmentTrace()
+			res, err := st.service().pickFromPools(context.Background(), poolPick{
+				teams:   []string{"backend"},
+				primary: map[string]struct{}{"backend": {}},
+				exclude: map[string]struct{}{"author": {}},
+				n:       2,
+				now:     st.now,
+				trace:   trace,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(res.picked, tt.want) {
+				t.Fatalf("picked %v, want %v", res.picked, tt.want)
+			}
+
+			saturated := !slices.Contains(tt.want, "u1")
+			if (res.saturated == 1) != saturated {
+				t.Errorf("saturated = %d", res.saturated)
+			}
+			if c := trace.candidates[trace.index["u1"]]; saturated && c.Reason != domain.CandidateReasonAtCapacity {
+				t.Errorf("u1 explanation = %+v, want %s", c, domain.CandidateReasonAtCapacity)
+			}
+		})
+	}
+}
+
+func TestAssignInitialEveryoneAtCapacity(t *testing.T) {
+	zero := 0
+	st := newFakeStore(monday)
+	policy := domain.DefaultReviewPolicy()
+	policy.DefaultMaxOpenReviews = &zero
+	policy.MinReviewers = 1
+	policy.AllowUnderstaffed = true
+	st.addTeam("backend", policy, domain.User{UserID: "author"}, domain.User{UserID: "u1"}, domain.User{UserID: "u2"})
+
+	pr := domain.PullRequest{PullRequestID: "pr-1", AuthorID: "author", Status: domain.PullRequestStatusOpen}
+	_, err := st.service().assignInitial(context.Background(), &pr, st.users["author"], nil, monday)
+	if !errors.Is(err, domain.ErrNoCandidate) {
+		t.Fatalf("err = %v, want NO_CANDIDATE even with allow_understaffed", err)
+	}
+
+	// без кандидатов вовсе PR создается understaffed
+	for _, id := range []string{"u1", "u2"} {
+		u := st.users[id]
+		u.IsActive = false
+		st.users[id] = u
+	}
+	if _, err := st.service().assignInitial(context.Background(), &pr, st.users["author"], nil, monday); err != nil {
+		t.Fatal(err)
+	}
+	if len(pr.Reviewers) != 0 || !pr.Understaffed {
+		t.Errorf("pr = %+v, want no reviewers and understaffed", pr)
+	}
+}
+
+func TestPickFromPoolsOutOfOffice(t *testing.T) {
+	away := func(from, to time.Duration) domain.Unavailability {
+		return domain.Unavailability{UserID: "u1", StartsAt: monday.Add(from), EndsAt: monday.Add(to)}
+	}
+
+	tests := []struct {
+		name    string
+		periods []domain.Unavailability
+		at      time.Duration
+		want    []string
+	}{
+		{"before the period", []domain.Unavailability{away(time.Hour, 3*time.Hour)}, 0, []string{"u1", "u2"}},
+		{"period starts at the moment", []domain.Unavailability{away(time.Hour, 3*time.Hour)}, time.Hour, []string{"u2"}},
+		{"inside the period", []domain.Unavailability{away(time.Hour, 3*time.Hour)}, 2 * time.Hour, []string{"u2"}},
+		{"period ends at the moment", []domain.Unavailability{away(time.Hour, 3*time.Hour)}, 3 * time.Hour, []string{"u1", "u2"}},
+		{"after the period", []domain.Unavailability{away(time.Hour, 3*time.Hour)}, 4 * time.Hour, []string{"u1", "u2"}},
+		{
+			name:    "overlapping periods",
+			periods: []domain.Unavailability{away(time.Hour, 3*time.Hour), away(2*time.Hour, 5*time.Hour)},
+			at:      4 * time.Hour,
+			want:    []string{"u2"},
+		},
+		{
+			name:    "back after overlapping periods",
+			periods: []domain.Unavailability{away(time.Hour, 3*time.Hour), away(2*time.Hour, 5*time.Hour)},
+			at:      5 * time.Hour,
+			want:    []string{"u1", "u2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newFakeStore(monday.Add(tt.at))
+			policy := domain.DefaultReviewPolicy()
+			policy.ReviewerStrategy = domain.ReviewerStrategyFirstN
+			st.addTeam("backend", policy, domain.User{UserID: "author"}, domain.User{UserID: "u1"}, domain.User{UserID: "u2"})
+			st.away = tt.periods
+
+			trace := newAssignmentTrace()
+			res, err := st.service().pickFromPools(context.Background(), poolPick{
+				teams:   []string{"backend"},
+				primary: map[string]struct{}{"backend": {}},
+				exclude: map[string]struct{}{"author": {}},
+				n:       2,
+				now:     st.now,
+				trace:   trace,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(res.picked, tt.want) {
+				t.Fatalf("picked %v, want %v", res.picked, tt.want)
+			}
+			if c := trace.candidates[trace.index["u1"]]; !slices.Contains(tt.want, "u1") && c.Reason != domain.CandidateReasonOutOfOffice {
+				t.Errorf("u1 explanation = %+v, want %s", c, domain.CandidateReasonOutOfOffice)
+			}
+		})
+	}
+}
+
+func TestByRecentPairs(t *testing.T) {
+	users := []domain.User{{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"}, {UserID: "u4"}}
+
+	tiers := byRecentPairs(users, map[string]int{"u1": 2, "u3": 1})
+	got := make([][]string, 0, len(tiers))
+	for _, tier := range tiers {
+		got = append(got, userIDs(tier))
+	}
+	want := [][]string{{"u2", "u4"}, {"u3"}, {"u1"}}
+	if !slices.EqualFunc(got, want, slices.Equal[[]string]) {
+		t.Errorf("tiers = %v, want %v", got, want)
+	}
+
+	if tiers := byRecentPairs(users, nil); len(tiers) != 1 || len(tiers[0]) != len(users) {
+		t.Errorf("without history tiers = %v, want everyone in one tier", tiers)
+	}
+}
+
+func TestAssignInitialPairDiversity(t *testing.T) {
+	tests := []struct {
+		name   string
+		window int
+		want   string
+	}{
+		{"history disabled", 0, "u1"},
+		{"recent reviewer is penalized", 1, "u2"},
+		{"fewer pairs preferred", 2, "u3"},
+		{"everyone reviewed recently, fall back to strategy", 3, "u1"},
+		{"window counts repeated pairs", 4, "u2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newFakeStore(monday)
+			policy := domain.DefaultReviewPolicy()
+			policy.ReviewerStrategy = domain.ReviewerStrategyFirstN
+			policy.MaxReviewers = 1
+			policy.PairDiversityWindow = tt.window
+			st.addTeam("backend", policy, domain.User{UserID: "author"},
+				domain.User{UserID: "u1"}, domain.User{UserID: "u2"}, domain.User{UserID: "u3"})
+			// от новых к старым: u1, u2, u3, u1
+			st.addPR("recent-1", "author", "u1")
+			st.addPR("recent-2", "author", "u2")
+			st.addPR("recent-3", "author", "u3")
+			st.addPR("recent-4", "author", "u1")
+
+			pr := domain.PullRequest{PullRequestID: "pr-1", AuthorID: "author", Status: domain.PullRequestStatusOpen}
+			trace, err := st.service().assignInitial(context.Background(), &pr, st.users["author"], nil, monday)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := pr.ReviewerIDs(); !slices.Equal(got, []string{tt.want}) {
+				t.Fatalf("reviewers = %v, want [%s]", got, tt.want)
+			}
+
+			// штраф виден в объяснении
+			if tt.window > 0 {
+				c := trace.candidates[trace.index["u1"]]
+				if !strings.Contains(c.Detail, "of author's recent PRs") {
+					t.Errorf("u1 explanation = %+v, want recent pairs in detail", c)
+				}
+			}
+		})
+	}
+}
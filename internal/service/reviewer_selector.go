@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/repo"
+	"sort"
+)
+
+// ReviewerSelector выбирает до n ревьюверов из кандидатов команды.
+// Кандидаты уже отфильтрованы: автор и назначенные ревьюверы исключены.
+type ReviewerSelector interface {
+	Select(ctx context.Context, teamName string, candidates []domain.User, n int) ([]string, error)
+}
+
+func newReviewerSelectors(prs repo.PullRequest, teams repo.Team) map[domain.ReviewerStrategy]ReviewerSelector {
+	return map[domain.ReviewerStrategy]ReviewerSelector{
+		domain.ReviewerStrategyFirstN:      firstNSelector{},
+		domain.ReviewerStrategyRandom:      randomSelector{},
+		domain.ReviewerStrategyRoundRobin:  roundRobinSelector{teams: teams},
+		domain.ReviewerStrategyLeastLoaded: leastLoadedSelector{prs: prs},
+		domain.ReviewerStrategyWeighted:    weightedSelector{},
+	}
+}
+
+// первые n кандидатов в порядке, в котором их вернула БД
+type firstNSelector struct{}
+
+func (firstNSelector) Select(_ context.Context, _ string, candidates []domain.User, n int) ([]string, error) {
+	return firstN(userIDs(candidates), n), nil
+}
+
+// равновероятный выбор
+type randomSelector struct{}
+
+func (randomSelector) Select(_ context.Context, _ string, candidates []domain.User, n int) ([]string, error) {
+	ids := userIDs(candidates)
+	rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	return firstN(ids, n), nil
+}
+
+// по кругу в порядке user_id, позиция хранится в teams.round_robin_cursor.
+// Если заданы cursors, позиция читается и сдвигается в них, сохраняет ее вызывающий
+type roundRobinSelector struct {
+	teams   repo.Team
+	cursors map[string]string
+}
+
+func (s roundRobinSelector) Select(ctx context.Context, teamName string, candidates []domain.User, n int) ([]string, error) {
+	ids := userIDs(candidates)
+	if len(ids) == 0 || n <= 0 {
+		return nil, nil
+	}
+	sort.Strings(ids)
+
+	cursor, ok := s.cursors[teamName]
+	if !ok {
+		var err error
+		if cursor, err = s.teams.GetRoundRobinCursor(ctx, teamName); err != nil {
+			return nil, err
+		}
+	}
+
+	// начинаем с первого user_id после курсора, курсор мог уйти из команды
+	start := sort.SearchStrings(ids, cursor)
+	if start < len(ids) && ids[start] == cursor {
+		start++
+	}
+
+	if n > len(ids) {
+		n = len(ids)
+	}
+	picked := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		picked = append(picked, ids[(start+i)%len(ids)])
+	}
+
+	if s.cursors != nil {
+		s.cursors[teamName] = picked[len(picked)-1]
+		return picked, nil
+	}
+	if err := s.teams.SetRoundRobinCursor(ctx, teamName, picked[len(picked)-1]); err != nil {
+		return nil, err
+	}
+	return picked, nil
+}
+
+// наименее загруженные по числу OPEN PR на ревью, при равной нагрузке порядок случайный.
+// Если задан load, нагрузка берется из него, а не из БД
+type leastLoadedSelector struct {
+	prs  repo.PullRequest
+	load map[string]int
+}
+
+func (s leastLoadedSelector) Select(ctx context.Context, _ string, candidates []domain.User, n int) ([]string, error) {
+	ids := userIDs(candidates)
+
+	load := s.load
+	if load == nil {
+		var err error
+		if load, err = s.prs.GetOpenReviewLoad(ctx, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	sort.SliceStable(ids, func(i, j int) bool { return load[ids[i]] < load[ids[j]] })
+
+	return firstN(ids, n), nil
+}
+
+// случайный выбор без повторов с вероятностью, пропорциональной users.review_weight.
+// Кандидаты с нулевым весом выбираются только если больше некого
+type weightedSelector struct{}
+
+func (weightedSelector) Select(_ context.Context, _ string, candidates []domain.User, n int) ([]string, error) {
+	type scored struct {
+		id  string
+		key float64
+	}
+
+	// алгоритм Efraimidis–Spirakis: ключ u^(1/w), берем n наибольших
+	items := make([]scored, 0, len(candidates))
+	for _, u := range candidates {
+		key := -1.0
+		if u.ReviewWeight > 0 {
+			key = math.Pow(rand.Float64(), 1/float64(u.ReviewWeight))
+		}
+		items = append(items, scored{id: u.UserID, key: key})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].key > items[j].key })
+
+	ids := make([]string, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.id)
+	}
+	return firstN(ids, n), nil
+}
+
+func userIDs(users []domain.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	return ids
+}
+
+func firstN(ids []string, n int) []string {
+	if n < 0 {
+		n = 0
+	}
+	if len(ids) > n {
+		return ids[:n]
+	}
+	return ids
+}
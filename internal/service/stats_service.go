@@ -8,27 +8,37 @@ import (
 
 type StatsService struct {
 	stats repo.Stats
+	repos repo.Repository
 }
 
-func NewStatsService(stats repo.Stats) *StatsService {
-	return &StatsService{stats: stats}
+func NewStatsService(stats repo.Stats, repos repo.Repository) *StatsService {
+	return &StatsService{stats: stats, repos: repos}
 }
 
-func (s *StatsService) GetStats(ctx context.Context) (*domain.StatsResponse, error) {
-	total, open, merged, err := s.stats.GetPRCounts(ctx)
+// repository = "" - статистика по всем репозиториям
+func (s *StatsService) GetStats(ctx context.Context, repository string) (*domain.StatsResponse, error) {
+	if repository != "" {
+		if _, err := s.repos.GetByName(ctx, repository); err != nil {
+			return nil, err
+		}
+	}
+
+	counts, err := s.stats.GetPRCounts(ctx, repository)
 	if err != nil {
 		return nil, err
 	}
 
-	reviewers, err := s.stats.GetReviewerStats(ctx)
+	reviewers, err := s.stats.GetReviewerStats(ctx, repository)
 	if err != nil {
 		return nil, err
 	}
 
 	return &domain.StatsResponse{
-		TotalPR:   total,
-		OpenPR:    open,
-		MergedPR:  merged,
+		TotalPR:   counts.Total,
+		DraftPR:   counts.Draft,
+		OpenPR:    counts.Open,
+		MergedPR:  counts.Merged,
+		ClosedPR:  counts.Closed,
 		Reviewers: reviewers,
 	}, nil
 }
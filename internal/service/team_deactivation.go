@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/repo"
+)
+
+// перераспределяет OPEN PR деактивированных участников команды между оставшимися
+// активными участниками. Все чтения батчевые: PR с ревьюверами, участники, нагрузка и
+// история пар читаются заранее, замены и курсор round_robin пишутся один раз в конце.
+// Замена выбирается pickFromTiers по тем же правилам, что reassign внутри команды,
+// при этом нагрузка и курсор учитывают назначения этой же операции.
+// Вызывается внутри транзакции под assignmentLockKey
+func (s *PRService) redistributeReviews(ctx context.Context, teamName string, deactivated []string) (domain.ReassignmentReport, error) {
+	report := domain.ReassignmentReport{
+		Reassigned:    make([]domain.ReviewReassignment, 0),
+		NotReassigned: make([]domain.ReviewReassignment, 0),
+	}
+	now := s.clock.Now()
+
+	prs, err := s.prs.GetOpenByReviewers(ctx, deactivated)
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+	if len(prs) == 0 {
+		return report, nil
+	}
+
+	members, err := s.users.GetByTeam(ctx, teamName)
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+	candidates, err := s.users.GetActiveByTeam(ctx, teamName, now)
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+	load, err := s.prs.GetOpenReviewLoad(ctx, userIDs(candidates))
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+	teamPolicy, err := s.teams.GetReviewPolicy(ctx, teamName)
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+
+	// политика команды автора решает, можно ли снять ревьювера без замены
+	authorIDs := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		authorIDs = append(authorIDs, pr.AuthorID)
+	}
+	authors, err := s.users.GetByIDs(ctx, authorIDs)
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+	// теги оставшихся ревьюверов: закрывают ли они метки PR
+	reviewerIDs := make([]string, 0)
+	for _, pr := range prs {
+		reviewerIDs = append(reviewerIDs, pr.ReviewerIDs()...)
+	}
+	reviewers, err := s.users.GetByIDs(ctx, reviewerIDs)
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+	known := make(map[string]domain.User, len(reviewers)+len(candidates))
+	for _, u := range append(reviewers, candidates...) {
+		known[u.UserID] = u
+	}
+	conflicts, err := s.exclusion.GetExcludedFor(ctx, authorIDs)
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+	authorTeam := make(map[string]string, len(authors))
+	policies := map[string]domain.ReviewPolicy{teamName: teamPolicy}
+	for _, a := range authors {
+		authorTeam[a.UserID] = a.TeamName
+		if _, ok := policies[a.TeamName]; ok || a.TeamName == "" {
+			continue
+		}
+		p, err := s.teams.GetReviewPolicy(ctx, a.TeamName)
+		if err != nil {
+			return domain.ReassignmentReport{}, err
+		}
+		policies[a.TeamName] = p
+	}
+	policyFor := func(pr domain.PullRequest) domain.ReviewPolicy {
+		if p, ok := policies[authorTeam[pr.AuthorID]]; ok {
+			return p
+		}
+		return domain.DefaultReviewPolicy()
+	}
+
+	windows := make([]repo.PairWindow, 0, len(prs))
+	for _, pr := range prs {
+		windows = append(windows, repo.PairWindow{
+			PullRequestID: pr.PullRequestID,
+			AuthorID:      pr.AuthorID,
+			Window:        policyFor(pr).PairDiversityWindow,
+		})
+	}
+	recent, err := s.prs.GetRecentPairCountsBatch(ctx, windows)
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+
+	// least_loaded видит нагрузку вместе с назначениями этой операции,
+	// round_robin двигает курсор в памяти
+	selectors := maps.Clone(s.selectors)
+	selectors[domain.ReviewerStrategyLeastLoaded] = leastLoadedSelector{load: load}
+	cursors := make(map[string]string)
+	selectors[domain.ReviewerStrategyRoundRobin] = roundRobinSelector{teams: s.teams, cursors: cursors}
+	teamLimits := map[string]*int{teamName: teamPolicy.DefaultMaxOpenReviews}
+
+	isDeactivated := make(map[string]struct{}, len(deactivated))
+	for _, id := range deactivated {
+		isDeactivated[id] = struct{}{}
+	}
+
+	changes := make([]repo.ReviewerChange, 0)
+	explanations := make([]domain.AssignmentExplanation, 0)
+	events := make([]domain.PREvent, 0)
+	for _, pr := range prs {
+		policy := policyFor(pr)
+
+		for _, oldID := range pr.ReviewerIDs() {
+			if _, ok := isDeactivated[oldID]; !ok {
+				continue
+			}
+
+			trace := newAssignmentTrace()
+			trace.skip(pr.AuthorID, authorTeam[pr.AuthorID], domain.CandidateReasonAuthor, "")
+			trace.skip(oldID, teamName, domain.CandidateReasonReplaced, "member deactivated")
+
+			exclude := make(map[string]struct{}, len(pr.Reviewers)+1)
+			exclude[pr.AuthorID] = struct{}{}
+			labels := tagSet(pr.Labels)
+			matched := false
+			for _, id := range pr.ReviewerIDs() {
+				exclude[id] = struct{}{}
+				if id == oldID {
+					continue
+				}
+				trace.skip(id, known[id].TeamName, domain.CandidateReasonAlreadyAssigned, "")
+				matched = matched || hasTag(known[id], labels)
+			}
+			for _, id := range conflicts[pr.AuthorID] {
+				if _, ok := exclude[id]; ok {
+					continue
+				}
+				exclude[id] = struct{}{}
+				trace.skip(id, "", domain.CandidateReasonConflict, "excluded with author")
+			}
+			traceMembers(trace, teamName, members, candidates, exclude)
+
+			available, saturated := splitByLoad(candidates, exclude, load, teamLimits)
+			for _, u := range saturated {
+				trace.skip(u.UserID, teamName, domain.CandidateReasonAtCapacity, "max_open_reviews reached")
+			}
+
+			picked, fallback, err := pickFromTiers(ctx, selectors, tierPick{
+				pools:   []teamPool{{team: teamName, strategy: teamPolicy.ReviewerStrategy, users: available}},
+				primary: map[string]struct{}{authorTeam[pr.AuthorID]: {}},
+				skip:    exclude,
+				labels:  labels,
+				matched: matched,
+				n:       1,
+				recent:  recent[pr.PullRequestID],
+				now:     now,
+				trace:   trace,
+			})
+			if err != nil {
+				return domain.ReassignmentReport{}, err
+			}
+			newID := ""
+			if len(picked) > 0 {
+				newID = picked[0]
+			}
+			// как и в reassign, снять ревьювера без замены можно, только если кандидатов нет вовсе
+			if newID == "" && (len(saturated) > 0 || !policy.AllowUnderstaffed) {
+				report.NotReassigned = append(report.NotReassigned, domain.ReviewReassignment{
+					PullRequestID: pr.PullRequestID,
+					OldReviewerID: oldID,
+					Error:         domain.ErrorNoCandidate,
+				})
+				continue
+			}
+
+			change := repo.ReviewerChange{
+				PullRequestID: pr.PullRequestID,
+				OldReviewerID: oldID,
+				NewReviewerID: newID,
+				FromFallback:  len(fallback) > 0,
+				AssignedAt:    now,
+			}
+			pr.FallbackReviewers = removeID(pr.FallbackReviewers, oldID)
+			if newID == "" {
+				pr.RemoveReviewer(oldID)
+			} else {
+				pr.ReplaceReviewer(oldID, newID, now)
+				if change.FromFallback {
+					pr.FallbackReviewers = append(pr.FallbackReviewers, newID)
+				}
+				load[newID]++
+			}
+			pr.Understaffed = len(pr.Reviewers) < policy.MinReviewers
+			change.Understaffed = pr.Understaffed
+
+			changes = append(changes, change)
+			if newID == "" {
+				events = append(events, domain.PREvent{
+					PullRequestID: pr.PullRequestID,
+					Type:          domain.PREventReviewerRemoved,
+					ReviewerID:    oldID,
+					Reason:        domain.PREventReasonDeactivation,
+					CreatedAt:     now,
+				})
+			} else {
+				events = append(events, domain.PREvent{
+					PullRequestID: pr.PullRequestID,
+					Type:          domain.PREventReviewerReassigned,
+					OldReviewerID: oldID,
+					NewReviewerID: newID,
+					Reason:        domain.PREventReasonDeactivation,
+					CreatedAt:     now,
+				})
+			}
+			explanations = append(explanations, domain.AssignmentExplanation{
+				PullRequestID:      pr.PullRequestID,
+				Action:             domain.AssignmentActionReassign,
+				ReplacedReviewerID: oldID,
+				CreatedAt:          now,
+				Candidates:         trace.candidates,
+			})
+			report.Reassigned = append(report.Reassigned, domain.ReviewReassignment{
+				PullRequestID: pr.PullRequestID,
+				OldReviewerID: oldID,
+				ReplacedBy:    newID,
+			})
+		}
+	}
+
+	if err := s.prs.ApplyReviewerChanges(ctx, changes, explanations); err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+	for team, cursor := range cursors {
+		if err := s.teams.SetRoundRobinCursor(ctx, team, cursor); err != nil {
+			return domain.ReassignmentReport{}, err
+		}
+	}
+	if err := s.prs.AddEvents(ctx, events); err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+
+	return report, nil
+}
+
+// traceUnavailable по заранее прочитанным участникам команды
+func traceMembers(trace *assignmentTrace, team string, members, available []domain.User, exclude map[string]struct{}) {
+	availableIDs := make(map[string]struct{}, len(available))
+	for _, u := range available {
+		availableIDs[u.UserID] = struct{}{}
+	}
+
+	for _, m := range members {
+		if _, ok := exclude[m.UserID]; ok {
+			trace.skip(m.UserID, team, domain.CandidateReasonAlreadyAssigned, "")
+			continue
+		}
+		if !m.IsActive {
+			trace.skip(m.UserID, team, domain.CandidateReasonInactive, "")
+			continue
+		}
+		if _, ok := availableIDs[m.UserID]; !ok {
+			trace.skip(m.UserID, team, domain.CandidateReasonOutOfOffice, "")
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-service/internal/domain"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPickFromTiers(t *testing.T) {
+	now := time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)
+	night, morning := "22:00", "23:00"
+	user := func(id string, tags ...string) domain.User {
+		return domain.User{UserID: id, TeamName: "backend", IsActive: true, Tags: tags}
+	}
+	offHours := func(u domain.User) domain.User {
+		u.TimeZone, u.WorkStart, u.WorkEnd = "UTC", &night, &morning
+		return u
+	}
+
+	tests := []struct {
+		name     string
+		strategy domain.ReviewerStrategy
+		users    []domain.User
+		labels   []string
+		recent   map[string]int
+		load     map[string]int
+		want     string
+	}{
+		{
+			name:     "strategy of the team",
+			strategy: domain.ReviewerStrategyLeastLoaded,
+			users:    []domain.User{user("u1"), user("u2"), user("u3")},
+			load:     map[string]int{"u1": 3, "u2": 1, "u3": 2},
+			want:     "u2",
+		},
+		{
+			name:     "tags matching labels first",
+			strategy: domain.ReviewerStrategyFirstN,
+			users:    []domain.User{user("u1", "go"), user("u2", "db")},
+			labels:   []string{"db"},
+			want:     "u2",
+		},
+		{
+			name:     "working now first",
+			strategy: domain.ReviewerStrategyFirstN,
+			users:    []domain.User{offHours(user("u1")), user("u2")},
+			want:     "u2",
+		},
+		{
+			name:     "rare pairs first",
+			strategy: domain.ReviewerStrategyFirstN,
+			users:    []domain.User{user("u1"), user("u2"), user("u3")},
+			recent:   map[string]int{"u1": 2, "u2": 1},
+			want:     "u3",
+		},
+		{
+			name:     "frequent pair if nobody else",
+			strategy: domain.ReviewerStrategyFirstN,
+			users:    []domain.User{user("u1")},
+			recent:   map[string]int{"u1": 2},
+			want:     "u1",
+		},
+		{
+			name:     "no candidates",
+			strategy: domain.ReviewerStrategyFirstN,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selectors := map[domain.ReviewerStrategy]ReviewerSelector{
+				domain.ReviewerStrategyFirstN:      firstNSelector{},
+				domain.ReviewerStrategyLeastLoaded: leastLoadedSelector{load: tt.load},
+			}
+			trace := newAssignmentTrace()
+
+			picked, fallback, err := pickFromTiers(context.Background(), selectors, tierPick{
+				pools:   []teamPool{{team: "backend", strategy: tt.strategy, users: tt.users}},
+				primary: map[string]struct{}{"backend": {}},
+				skip:    map[string]struct{}{},
+				labels:  tagSet(tt.labels),
+				n:       1,
+				recent:  tt.recent,
+				now:     now,
+				trace:   trace,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ""
+			if len(picked) > 0 {
+				got = picked[0]
+			}
+			if got != tt.want {
+				t.Fatalf("picked %q, want %q", got, tt.want)
+			}
+			if len(fallback) != 0 {
+				t.Errorf("fallback = %v, want none from the primary team", fallback)
+			}
+
+			// каждый кандидат попадает в объяснение, выбран только один
+			if len(trace.candidates) != len(tt.users) {
+				t.Fatalf("trace has %d candidates, want %d", len(trace.candidates), len(tt.users))
+			}
+			for _, c := range trace.candidates {
+				if c.Picked != (c.UserID == tt.want) {
+					t.Errorf("candidate %s: picked = %v", c.UserID, c.Picked)
+				}
+				if !c.Picked && c.Reason != domain.CandidateReasonNotSelected {
+					t.Errorf("candidate %s: reason %s, want %s", c.UserID, c.Reason, domain.CandidateReasonNotSelected)
+				}
+			}
+		})
+	}
+}
+
+// команда backend деактивирует d1 и d2, авторы PR из frontend
+func newDeactivationStore(strategy domain.ReviewerStrategy) *fakeStore {
+	st := newFakeStore(monday)
+	policy := domain.DefaultReviewPolicy()
+	policy.ReviewerStrategy = strategy
+	st.addTeam("backend", policy,
+		domain.User{UserID: "c1"}, domain.User{UserID: "c2"}, domain.User{UserID: "c3"},
+		domain.User{UserID: "d1"}, domain.User{UserID: "d2"})
+	st.addTeam("frontend", domain.DefaultReviewPolicy(), domain.User{UserID: "author"}, domain.User{UserID: "f1"})
+	for _, id := range []string{"d1", "d2"} {
+		u := st.users[id]
+		u.IsActive = false
+		st.users[id] = u
+	}
+	return st
+}
+
+func TestRedistributeReviews(t *testing.T) {
+	t.Run("load counts replacements of the same run", func(t *testing.T) {
+		st := newDeactivationStore(domain.ReviewerStrategyLeastLoaded)
+		st.addPR("pr-1", "author", "d1")
+		st.addPR("pr-2", "author", "d2")
+		st.addPR("busy", "f1", "c3")
+
+		report, err := st.service().redistributeReviews(context.Background(), "backend", []string{"d1", "d2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(report.Reassigned) != 2 || len(report.NotReassigned) != 0 {
+			t.Fatalf("report = %+v", report)
+		}
+
+		got := make(map[string]int)
+		for _, r := range report.Reassigned {
+			got[r.ReplacedBy]++
+			pr := st.pr(r.PullRequestID)
+			if !slices.Equal(pr.ReviewerIDs(), []string{r.ReplacedBy}) {
+				t.Errorf("%s reviewers = %v, want [%s]", r.PullRequestID, pr.ReviewerIDs(), r.ReplacedBy)
+			}
+			// команда замены не совпадает с командой автора
+			if !slices.Equal(pr.FallbackReviewers, []string{r.ReplacedBy}) {
+				t.Errorf("%s fallback = %v, want [%s]", r.PullRequestID, pr.FallbackReviewers, r.ReplacedBy)
+			}
+		}
+		// c3 уже занят, c1 и c2 получают по PR, а не оба одному
+		if got["c1"] != 1 || got["c2"] != 1 {
+			t.Errorf("replacements = %v, want one PR for c1 and c2", got)
+		}
+		if len(st.explanations) != 2 || len(st.events) != 2 {
+			t.Errorf("explanations = %d, events = %d, want 2 each", len(st.explanations), len(st.events))
+		}
+	})
+
+	t.Run("round robin cursor is written once", func(t *testing.T) {
+		st := newDeactivationStore(domain.ReviewerStrategyRoundRobin)
+		st.addPR("pr-1", "author", "d1")
+		st.addPR("pr-2", "author", "d1")
+
+		report, err := st.service().redistributeReviews(context.Background(), "backend", []string{"d1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(report.Reassigned) != 2 || report.Reassigned[0].ReplacedBy != "c1" || report.Reassigned[1].ReplacedBy != "c2" {
+			t.Fatalf("reassigned = %+v, want c1 then c2", report.Reassigned)
+		}
+		if team := st.teams["backend"]; team.cursor != "c2" || team.cursorWrites != 1 {
+			t.Errorf("cursor = %q after %d writes, want c2 after 1", team.cursor, team.cursorWrites)
+		}
+	})
+
+	t.Run("labels and remaining reviewers", func(t *testing.T) {
+		st := newDeactivationStore(domain.ReviewerStrategyFirstN)
+		c2 := st.users["c2"]
+		c2.Tags = []string{"db"}
+		st.users["c2"] = c2
+		st.addPR("pr-1", "author", "d1", "c1")
+		pr := st.prs["pr-1"]
+		pr.Labels = []string{"db"}
+		st.prs["pr-1"] = pr
+
+		report, err := st.service().redistributeReviews(context.Background(), "backend", []string{"d1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(report.Reassigned) != 1 || report.Reassigned[0].ReplacedBy != "c2" {
+			t.Fatalf("reassigned = %+v, want c2 with the matching tag", report.Reassigned)
+		}
+		if got := st.pr("pr-1").ReviewerIDs(); !slices.Equal(got, []string{"c2", "c1"}) && !slices.Equal(got, []string{"c1", "c2"}) {
+			t.Errorf("reviewers = %v", got)
+		}
+	})
+
+	t.Run("understaffed is recomputed", func(t *testing.T) {
+		st := newDeactivationStore(domain.ReviewerStrategyFirstN)
+		st.teams["frontend"].policy.MinReviewers = 1
+		st.teams["frontend"].policy.AllowUnderstaffed = true
+		st.addPR("pr-1", "author", "d1")
+		pr := st.prs["pr-1"]
+		pr.Understaffed = true
+		st.prs["pr-1"] = pr
+
+		if _, err := st.service().redistributeReviews(context.Background(), "backend", []string{"d1"}); err != nil {
+			t.Fatal(err)
+		}
+		if st.pr("pr-1").Understaffed {
+			t.Error("PR with enough reviewers after replacement is still understaffed")
+		}
+	})
+
+	t.Run("no candidates", func(t *testing.T) {
+		tests := []struct {
+			name              string
+			allowUnderstaffed bool
+			saturated         bool
+			wantRemoved       bool
+		}{
+			{name: "understaffed not allowed"},
+			{name: "understaffed allowed", allowUnderstaffed: true, wantRemoved: true},
+			{name: "everyone at capacity", allowUnderstaffed: true, saturated: true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				st := newDeactivationStore(domain.ReviewerStrategyFirstN)
+				st.teams["frontend"].policy.MinReviewers = 1
+				st.teams["frontend"].policy.AllowUnderstaffed = tt.allowUnderstaffed
+				zero := 0
+				for _, id := range []string{"c1", "c2", "c3"} {
+					u := st.users[id]
+					if tt.saturated {
+						u.MaxOpenReviews = &zero
+					} else {
+						u.IsActive = false
+					}
+					st.users[id] = u
+				}
+				st.addPR("pr-1", "author", "d1")
+
+				report, err := st.service().redistributeReviews(context.Background(), "backend", []string{"d1"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				pr := st.pr("pr-1")
+				if tt.wantRemoved {
+					if len(report.Reassigned) != 1 || report.Reassigned[0].ReplacedBy != "" || len(pr.Reviewers) != 0 || !pr.Understaffed {
+						t.Errorf("report = %+v, pr = %+v, want reviewer removed and PR understaffed", report, pr)
+					}
+					return
+				}
+				if len(report.NotReassigned) != 1 || report.NotReassigned[0].Error != domain.ErrorNoCandidate ||
+					report.NotReassigned[0].OldReviewerID != "d1" {
+					t.Errorf("not reassigned = %+v, want NO_CANDIDATE for d1", report.NotReassigned)
+				}
+				if !slices.Equal(pr.ReviewerIDs(), []string{"d1"}) {
+					t.Errorf("reviewers = %v, want [d1] untouched", pr.ReviewerIDs())
+				}
+			})
+		}
+	})
+}
+
+func TestSplitByLoad(t *testing.T) {
+	one, three := 1, 3
+	users := []domain.User{
+		{UserID: "author", TeamName: "backend"},
+		{UserID: "own-limit", TeamName: "backend", MaxOpenReviews: &one},
+		{UserID: "team-limit", TeamName: "backend"},
+		{UserID: "free", TeamName: "backend"},
+	}
+	load := map[string]int{"own-limit": 1, "team-limit": 3, "free": 2}
+
+	available, saturated := splitByLoad(users, map[string]struct{}{"author": {}}, load, map[string]*int{"backend": &three})
+
+	if ids := userIDs(available); len(ids) != 1 || ids[0] != "free" {
+		t.Errorf("available = %v, want [free]", ids)
+	}
+	if ids := userIDs(saturated); len(ids) != 2 || ids[0] != "own-limit" || ids[1] != "team-limit" {
+		t.Errorf("saturated = %v, want [own-limit team-limit]", ids)
+	}
+}
@@ -2,20 +2,28 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"pr-reviewer-service/internal/domain"
 	"pr-reviewer-service/internal/repo"
 )
 
 type TeamService struct {
-	teams repo.Team
-	users repo.User
+	teams   repo.Team
+	users   repo.User
+	tx      repo.Transactor
+	reviews *PRService
 }
 
-func NewTeamService(teams repo.Team, users repo.User) *TeamService {
-	return &TeamService{teams: teams, users: users}
+func NewTeamService(teams repo.Team, users repo.User, tx repo.Transactor, reviews *PRService) *TeamService {
+	return &TeamService{teams: teams, users: users, tx: tx, reviews: reviews}
 }
 
 func (s *TeamService) CreateTeam(ctx context.Context, team domain.Team) (domain.Team, error) {
+	if err := team.ReviewPolicy.Validate(); err != nil {
+		return domain.Team{}, err
+	}
+
 	exists, err := s.teams.Exists(ctx, team.TeamName)
 	if err != nil {
 		return domain.Team{}, err
@@ -24,6 +32,10 @@ func (s *TeamService) CreateTeam(ctx context.Context, team domain.Team) (domain.
 		return domain.Team{}, domain.ErrTeamExists
 	}
 
+	if err := s.checkFallbackTeams(ctx, team.TeamName, team.ReviewPolicy.FallbackTeams); err != nil {
+		return domain.Team{}, err
+	}
+
 	if err := s.teams.Create(ctx, team); err != nil {
 		return domain.Team{}, err
 	}
@@ -38,3 +50,142 @@ func (s *TeamService) CreateTeam(ctx context.Context, team domain.Team) (domain.
 func (s *TeamService) GetTeam(ctx context.Context, teamName string) (domain.Team, error) {
 	return s.teams.GetByName(ctx, teamName)
 }
+
+// apply получает текущую политику команды и возвращает новую
+func (s *TeamService) UpdateReviewPolicy(ctx context.Context, teamName string, apply func(domain.ReviewPolicy) domain.ReviewPolicy) (domain.ReviewPolicy, error) {
+	current, err := s.teams.GetReviewPolicy(ctx, teamName)
+	if err != nil {
+		return domain.ReviewPolicy{}, err
+	}
+
+	policy := apply(current)
+	if err := policy.Validate(); err != nil {
+		return domain.ReviewPolicy{}, err
+	}
+	if err := s.checkFallbackTeams(ctx, teamName, policy.FallbackTeams); err != nil {
+		return domain.ReviewPolicy{}, err
+	}
+
+	if err := s.teams.UpdateReviewPolicy(ctx, teamName, policy); err != nil {
+		return domain.ReviewPolicy{}, err
+	}
+	return policy, nil
+}
+
+// SetMergePolicy целиком заменяет условия merge. Тимлид должен состоять в команде
+func (s *TeamService) SetMergePolicy(ctx context.Context, teamName string, policy domain.MergePolicy) (domain.MergePolicy, error) {
+	if _, err := s.teams.GetMergePolicy(ctx, teamName); err != nil {
+		return domain.MergePolicy{}, err
+	}
+
+	if err := policy.Validate(); err != nil {
+		return domain.MergePolicy{}, err
+	}
+	if policy.TeamLeadID != "" {
+		lead, err := s.users.GetByID(ctx, policy.TeamLeadID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.MergePolicy{}, fmt.Errorf("%w: team lead %q not found", domain.ErrInvalidPolicy, policy.TeamLeadID)
+			}
+			return domain.MergePolicy{}, err
+		}
+		if lead.TeamName != teamName {
+			return domain.MergePolicy{}, fmt.Errorf("%w: team lead %q is not a member of the team", domain.ErrInvalidPolicy, policy.TeamLeadID)
+		}
+	}
+
+	if err := s.teams.UpdateMergePolicy(ctx, teamName, policy); err != nil {
+		return domain.MergePolicy{}, err
+	}
+	return policy, nil
+}
+
+// SetReviewSLA целиком заменяет сроки ответа ревьюверов. Пользователь для эскалации
+// может быть из любой команды, но должен быть активен
+func (s *TeamService) SetReviewSLA(ctx context.Context, teamName string, sla domain.ReviewSLA) (domain.ReviewSLA, error) {
+	if _, err := s.teams.GetReviewSLA(ctx, teamName); err != nil {
+		return domain.ReviewSLA{}, err
+	}
+
+	if err := sla.Validate(); err != nil {
+		return domain.ReviewSLA{}, err
+	}
+	if sla.EscalationHours == 0 {
+		sla.EscalationAction = ""
+		sla.EscalationUserID = ""
+	}
+	if sla.EscalationAction != domain.EscalationActionAddUser {
+		sla.EscalationUserID = ""
+	}
+	if sla.EscalationUserID != "" {
+		u, err := s.users.GetByID(ctx, sla.EscalationUserID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ReviewSLA{}, fmt.Errorf("%w: escalation user %q not found", domain.ErrInvalidPolicy, sla.EscalationUserID)
+			}
+			return domain.ReviewSLA{}, err
+		}
+		if !u.IsActive {
+			return domain.ReviewSLA{}, fmt.Errorf("%w: escalation user %q is not active", domain.ErrInvalidPolicy, sla.EscalationUserID)
+		}
+	}
+
+	if err := s.teams.UpdateReviewSLA(ctx, teamName, sla); err != nil {
+		return domain.ReviewSLA{}, err
+	}
+	return sla, nil
+}
+
+func (s *TeamService) checkFallbackTeams(ctx context.Context, teamName string, fallbacks []string) error {
+	for _, fb := range fallbacks {
+		if fb == teamName {
+			return fmt.Errorf("%w: team cannot be its own fallback", domain.ErrInvalidPolicy)
+		}
+
+		exists, err := s.teams.Exists(ctx, fb)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: fallback team %q not found", domain.ErrInvalidPolicy, fb)
+		}
+	}
+	return nil
+}
+
+// DeactivateMembers атомарно деактивирует участников команды и перераспределяет
+// их OPEN PR между оставшимися активными участниками. Если кого-то из userIDs
+// нет в команде, ничего не меняется и возвращается ErrNotFound
+func (s *TeamService) DeactivateMembers(ctx context.Context, teamName string, userIDs []string) (domain.ReassignmentReport, error) {
+	var report domain.ReassignmentReport
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.tx.Lock(ctx, assignmentLockKey); err != nil {
+			return err
+		}
+
+		exists, err := s.teams.Exists(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrNotFound
+		}
+
+		updated, err := s.users.DeactivateTeamMembers(ctx, teamName, userIDs)
+		if err != nil {
+			return err
+		}
+		if len(updated) != len(userIDs) {
+			return domain.ErrNotFound
+		}
+
+		report, err = s.reviews.redistributeReviews(ctx, teamName, userIDs)
+		return err
+	})
+	if err != nil {
+		return domain.ReassignmentReport{}, err
+	}
+
+	return report, nil
+}
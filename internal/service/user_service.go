@@ -7,38 +7,130 @@ import (
 )
 
 type UserService struct {
-	users repo.User
-	prs   repo.PullRequest
+	users          repo.User
+	prs            repo.PullRequest
+	unavailability repo.Unavailability
+	repos          repo.Repository
+	tx             repo.Transactor
+	reviews        *PRService
 }
 
-func NewUserService(users repo.User, prs repo.PullRequest) *UserService {
+func NewUserService(users repo.User, prs repo.PullRequest, unavailability repo.Unavailability, repos repo.Repository, tx repo.Transactor, reviews *PRService) *UserService {
 	return &UserService{
-		users: users,
-		prs:   prs,
+		users:          users,
+		prs:            prs,
+		unavailability: unavailability,
+		repos:          repos,
+		tx:             tx,
+		reviews:        reviews,
 	}
 }
 
-func (s *UserService) SetActive(ctx context.Context, userID string, isActive bool) (domain.User, error) {
-	if err := s.users.SetActive(ctx, userID, isActive); err != nil {
-		return domain.User{}, err
-	}
+// SetActive меняет флаг активности. При деактивации в той же транзакции
+// OPEN PR пользователя переназначаются на других ревьюверов
+func (s *UserService) SetActive(ctx context.Context, userID string, isActive bool) (domain.User, domain.ReassignmentReport, error) {
+	var (
+		u      domain.User
+		report domain.ReassignmentReport
+	)
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.tx.Lock(ctx, assignmentLockKey); err != nil {
+			return err
+		}
+
+		if err := s.users.SetActive(ctx, userID, isActive); err != nil {
+			return err
+		}
+
+		var err error
+		u, err = s.users.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
 
-	u, err := s.users.GetByID(ctx, userID)
+		if isActive {
+			report = domain.ReassignmentReport{
+				Reassigned:    make([]domain.ReviewReassignment, 0),
+				NotReassigned: make([]domain.ReviewReassignment, 0),
+			}
+			return nil
+		}
+
+		report, err = s.reviews.reassignOpenReviews(ctx, userID)
+		return err
+	})
 	if err != nil {
-		return domain.User{}, err
+		return domain.User{}, domain.ReassignmentReport{}, err
 	}
 
-	return u, nil
+	return u, report, nil
 }
 
-func (s *UserService) GetReviewPullRequests(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+// repository = "" - PR из всех репозиториев
+func (s *UserService) GetReviewPullRequests(ctx context.Context, userID, repository string) ([]domain.PullRequestShort, error) {
 	if _, err := s.users.GetByID(ctx, userID); err != nil {
 		return nil, err
 	}
+	if repository != "" {
+		if _, err := s.repos.GetByName(ctx, repository); err != nil {
+			return nil, err
+		}
+	}
 
-	prs, err := s.prs.GetByReviewer(ctx, userID)
+	prs, err := s.prs.GetByReviewer(ctx, userID, repository)
 	if err != nil {
 		return nil, err
 	}
 	return prs, nil
 }
+
+func (s *UserService) GetUser(ctx context.Context, userID string) (domain.User, error) {
+	return s.users.GetByID(ctx, userID)
+}
+
+func (s *UserService) SetTags(ctx context.Context, userID string, tags []string) (domain.User, error) {
+	if err := s.users.SetTags(ctx, userID, domain.NormalizeTags(tags)); err != nil {
+		return domain.User{}, err
+	}
+
+	return s.users.GetByID(ctx, userID)
+}
+
+func (s *UserService) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (domain.User, error) {
+	if err := s.users.SetMaxOpenReviews(ctx, userID, limit); err != nil {
+		return domain.User{}, err
+	}
+
+	return s.users.GetByID(ctx, userID)
+}
+
+func (s *UserService) SetWorkingHours(ctx context.Context, userID, timeZone string, start, end *string) (domain.User, error) {
+	if err := s.users.SetWorkingHours(ctx, userID, timeZone, start, end); err != nil {
+		return domain.User{}, err
+	}
+
+	return s.users.GetByID(ctx, userID)
+}
+
+func (s *UserService) AddUnavailability(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error) {
+	if _, err := s.users.GetByID(ctx, period.UserID); err != nil {
+		return domain.Unavailability{}, err
+	}
+
+	period.StartsAt = period.StartsAt.UTC()
+	period.EndsAt = period.EndsAt.UTC()
+	return s.unavailability.Create(ctx, period)
+}
+
+func (s *UserService) GetUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.unavailability.GetByUser(ctx, userID)
+}
+
+func (s *UserService) RemoveUnavailability(ctx context.Context, id int64) (domain.Unavailability, error) {
+	return s.unavailability.Delete(ctx, id)
+}
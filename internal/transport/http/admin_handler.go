@@ -0,0 +1,27 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"pr-reviewer-service/internal/scheduler"
+	"pr-reviewer-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	scheduler *scheduler.Scheduler
+	logger    *slog.Logger
+}
+
+func NewAdminHandler(scheduler *scheduler.Scheduler, logger *slog.Logger) *AdminHandler {
+	return &AdminHandler{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// GET /admin/jobs, состояние задач планировщика в этой реплике
+func (h *AdminHandler) Jobs(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.JobsResponse{Jobs: h.scheduler.Jobs()})
+}
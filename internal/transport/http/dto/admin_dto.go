@@ -0,0 +1,8 @@
+package dto
+
+import "pr-reviewer-service/internal/scheduler"
+
+// dto for response /admin/jobs
+type JobsResponse struct {
+	Jobs []scheduler.JobStatus `json:"jobs"`
+}
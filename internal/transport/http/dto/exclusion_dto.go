@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"pr-reviewer-service/internal/domain"
+)
+
+// dto for request /exclusions/add
+type AddExclusionRequest struct {
+	UserID      string `json:"user_id"`
+	OtherUserID string `json:"other_user_id"`
+	Reason      string `json:"reason"`
+}
+
+// dto for request /exclusions/remove
+type RemoveExclusionRequest struct {
+	ID int64 `json:"id"`
+}
+
+// dto for response /exclusions/add
+type AddExclusionResponse struct {
+	Exclusion  domain.ReviewExclusion      `json:"exclusion"`
+	Violations []domain.ExclusionViolation `json:"violations"` // OPEN PR, уже нарушающие новое правило
+}
+
+// dto for response /exclusions/remove
+type ExclusionResponse struct {
+	Exclusion domain.ReviewExclusion `json:"exclusion"`
+}
+
+// dto for response /exclusions/list
+type ListExclusionsResponse struct {
+	Exclusions []domain.ReviewExclusion `json:"exclusions"`
+}
+
+// dto for response /exclusions/violations
+type ExclusionViolationsResponse struct {
+	Violations []domain.ExclusionViolation `json:"violations"`
+}
+
+func (r *AddExclusionRequest) Validate() error {
+	if r.UserID == "" || r.OtherUserID == "" {
+		return errors.New("user_id and other_user_id are required")
+	}
+	if r.UserID == r.OtherUserID {
+		return errors.New("user_id and other_user_id must differ")
+	}
+	if len(r.Reason) > maxReasonLength {
+		return fmt.Errorf("reason is longer than %d characters", maxReasonLength)
+	}
+	return nil
+}
+
+func (r *RemoveExclusionRequest) Validate() error {
+	if r.ID <= 0 {
+		return errors.New("id is required")
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "pr-reviewer-service/internal/domain"
+
+// dto for response /ownership/upload and /ownership/rules
+type OwnershipRulesResponse struct {
+	Rules []domain.OwnershipRule `json:"rules"`
+}
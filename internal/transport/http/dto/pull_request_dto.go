@@ -2,17 +2,22 @@ package dto
 
 import (
 	"errors"
+	"fmt"
 	"pr-reviewer-service/internal/domain"
 )
 
 // dto for request /pullRequest/create
 type CreatePullRequestRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	ChangedFiles    []string `json:"changed_files,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	Draft           bool     `json:"draft,omitempty"` // черновик без ревьюверов
+	domain.PullRequestMetadata
 }
 
-// dto for response /pullRequest/create and /pullRequest/merge
+// dto for response /pullRequest/create, /pullRequest/merge and reviewer edits
 type PullRequestResponse struct {
 	PR domain.PullRequest `json:"pr"`
 }
@@ -20,20 +25,81 @@ type PullRequestResponse struct {
 // dto for request /pullRequest/merge
 type MergePullRequestRequest struct {
 	PullRequestID string `json:"pull_request_id"`
+	Force         bool   `json:"force,omitempty"` // merge в обход merge политики, фиксируется в PR
 }
 
 // dto for request /pullRequest/reassign
 type ReassignReviewerRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
+	NewUserID     string `json:"new_user_id,omitempty"` // пусто - замена подбирается автоматически
 }
 
 // dto for response /pullRequest/reassign
+// replaced_by пустой, если замены не нашлось и команда разрешает понижение числа ревьюверов
 type ReassignReviewerResponse struct {
 	PR         domain.PullRequest `json:"pr"`
 	ReplacedBy string             `json:"replaced_by"`
 }
 
+// dto for request /pullRequest/addReviewer and /pullRequest/removeReviewer
+type PullRequestReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+// dto for request PUT /pullRequest/reviewers, полностью заменяет список
+type SetReviewersRequest struct {
+	PullRequestID string   `json:"pull_request_id"`
+	Reviewers     []string `json:"reviewers"`
+}
+
+// dto for request /pullRequest/review
+type ReviewPullRequestRequest struct {
+	PullRequestID string             `json:"pull_request_id"`
+	ReviewerID    string             `json:"reviewer_id"`
+	State         domain.ReviewState `json:"state"`
+}
+
+// dto for request /pullRequest/close and /pullRequest/reopen
+type ChangeStatusRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// dto for request /pullRequest/markReady
+type MarkReadyRequest struct {
+	PullRequestID string   `json:"pull_request_id"`
+	ChangedFiles  []string `json:"changed_files,omitempty"`
+}
+
+// dto for request /pullRequest/approve
+type ApprovePullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+}
+
+// dto for response /pullRequest/assignmentExplain
+type AssignmentExplainResponse struct {
+	PullRequestID string                         `json:"pull_request_id"`
+	Assignments   []domain.AssignmentExplanation `json:"assignments"`
+}
+
+// dto for response /pullRequest/timeline
+type TimelineResponse struct {
+	PullRequestID string           `json:"pull_request_id"`
+	Events        []domain.PREvent `json:"events"`
+}
+
+// dto for response /pullRequest/overdue
+type OverdueReviewsResponse struct {
+	Reviews []domain.ReviewAge `json:"reviews"`
+}
+
+// dto for response /pullRequest/escalateOverdue
+type EscalateOverdueResponse struct {
+	Escalations []domain.ReviewEscalation `json:"escalations"`
+}
+
 func (r *CreatePullRequestRequest) Validate() error {
 	if r.PullRequestID == "" {
 		return errors.New("pull_request_id is required")
@@ -44,7 +110,17 @@ func (r *CreatePullRequestRequest) Validate() error {
 	if r.AuthorID == "" {
 		return errors.New("author_id is required")
 	}
-	return nil
+	for i, f := range r.ChangedFiles {
+		if f == "" {
+			return fmt.Errorf("changed_files[%d] must not be empty", i)
+		}
+	}
+	for i, l := range r.Labels {
+		if len(l) > maxTagLength {
+			return fmt.Errorf("labels[%d] is longer than %d characters", i, maxTagLength)
+		}
+	}
+	return r.PullRequestMetadata.Validate()
 }
 
 func (r *MergePullRequestRequest) Validate() error {
@@ -63,3 +139,76 @@ func (r *ReassignReviewerRequest) Validate() error {
 	}
 	return nil
 }
+
+func (r *PullRequestReviewerRequest) Validate() error {
+	if r.PullRequestID == "" {
+		return errors.New("pull_request_id is required")
+	}
+	if r.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	return nil
+}
+
+func (r *SetReviewersRequest) Validate() error {
+	if r.PullRequestID == "" {
+		return errors.New("pull_request_id is required")
+	}
+	if r.Reviewers == nil {
+		return errors.New("reviewers is required")
+	}
+	seen := make(map[string]struct{}, len(r.Reviewers))
+	for i, id := range r.Reviewers {
+		if id == "" {
+			return fmt.Errorf("reviewers[%d] must not be empty", i)
+		}
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("reviewers[%d] is duplicated", i)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+func (r *ReviewPullRequestRequest) Validate() error {
+	if r.PullRequestID == "" {
+		return errors.New("pull_request_id is required")
+	}
+	if r.ReviewerID == "" {
+		return errors.New("reviewer_id is required")
+	}
+	if !r.State.IsDecision() {
+		return fmt.Errorf("state must be one of %s, %s, %s",
+			domain.ReviewStateApproved, domain.ReviewStateChangesRequested, domain.ReviewStateCommented)
+	}
+	return nil
+}
+
+func (r *ApprovePullRequestRequest) Validate() error {
+	if r.PullRequestID == "" {
+		return errors.New("pull_request_id is required")
+	}
+	if r.ReviewerID == "" {
+		return errors.New("reviewer_id is required")
+	}
+	return nil
+}
+
+func (r *ChangeStatusRequest) Validate() error {
+	if r.PullRequestID == "" {
+		return errors.New("pull_request_id is required")
+	}
+	return nil
+}
+
+func (r *MarkReadyRequest) Validate() error {
+	if r.PullRequestID == "" {
+		return errors.New("pull_request_id is required")
+	}
+	for i, f := range r.ChangedFiles {
+		if f == "" {
+			return fmt.Errorf("changed_files[%d] must not be empty", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "pr-reviewer-service/internal/domain"
+
+// dto for response /pullRequest/reminders
+type RemindersResponse struct {
+	Reminders []domain.ReviewReminder `json:"reminders"`
+}
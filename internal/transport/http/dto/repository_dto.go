@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"pr-reviewer-service/internal/domain"
+	"strings"
+)
+
+// dto for request /repository/add
+type AddRepositoryRequest struct {
+	Name     string `json:"name"`
+	TeamName string `json:"team_name,omitempty"`
+	domain.RepositoryReviewPolicy
+}
+
+// dto for response /repository/add and /repository/get
+type RepositoryResponse struct {
+	Repository domain.Repository `json:"repository"`
+}
+
+// dto for response /repository/list
+type ListRepositoriesResponse struct {
+	Repositories []domain.Repository `json:"repositories"`
+}
+
+// dto for request /repository/pullRequest/create
+type CreateRepositoryPullRequestRequest struct {
+	Repository      string   `json:"repository"`
+	Number          string   `json:"number"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	ChangedFiles    []string `json:"changed_files,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	Draft           bool     `json:"draft,omitempty"`
+	domain.PullRequestMetadata
+}
+
+// dto for request /repository/pullRequest/merge
+type MergeRepositoryPullRequestRequest struct {
+	Repository string `json:"repository"`
+	Number     string `json:"number"`
+	Force      bool   `json:"force,omitempty"`
+}
+
+// dto for request /repository/pullRequest/reassign
+type ReassignRepositoryReviewerRequest struct {
+	Repository string `json:"repository"`
+	Number     string `json:"number"`
+	OldUserID  string `json:"old_user_id"`
+	NewUserID  string `json:"new_user_id,omitempty"`
+}
+
+// '#' разделяет репозиторий и номер в pull_request_id
+func (r *AddRepositoryRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if strings.Contains(r.Name, "#") {
+		return errors.New("name must not contain '#'")
+	}
+	return nil
+}
+
+func (r *CreateRepositoryPullRequestRequest) Validate() error {
+	if err := validatePRNumber(r.Repository, r.Number); err != nil {
+		return err
+	}
+	if r.PullRequestName == "" {
+		return errors.New("pull_request_name is required")
+	}
+	if r.AuthorID == "" {
+		return errors.New("author_id is required")
+	}
+	for i, f := range r.ChangedFiles {
+		if f == "" {
+			return fmt.Errorf("changed_files[%d] must not be empty", i)
+		}
+	}
+	for i, l := range r.Labels {
+		if len(l) > maxTagLength {
+			return fmt.Errorf("labels[%d] is longer than %d characters", i, maxTagLength)
+		}
+	}
+	return r.PullRequestMetadata.Validate()
+}
+
+func (r *MergeRepositoryPullRequestRequest) Validate() error {
+	return validatePRNumber(r.Repository, r.Number)
+}
+
+func (r *ReassignRepositoryReviewerRequest) Validate() error {
+	if err := validatePRNumber(r.Repository, r.Number); err != nil {
+		return err
+	}
+	if r.OldUserID == "" {
+		return errors.New("old_user_id is required")
+	}
+	return nil
+}
+
+func validatePRNumber(repository, number string) error {
+	if repository == "" {
+		return errors.New("repository is required")
+	}
+	if number == "" {
+		return errors.New("number is required")
+	}
+	return nil
+}
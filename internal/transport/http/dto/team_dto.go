@@ -8,8 +8,9 @@ import (
 
 // dto for request /team/add
 type TeamAddRequest struct {
-	TeamName string              `json:"team_name"`
-	Members  []domain.TeamMember `json:"members"`
+	TeamName     string              `json:"team_name"`
+	Members      []domain.TeamMember `json:"members"`
+	ReviewPolicy *ReviewPolicyPatch  `json:"review_policy,omitempty"`
 }
 
 // dto for response /team/add and /team/get
@@ -17,6 +18,69 @@ type TeamAddResponse struct {
 	Team domain.Team `json:"team"`
 }
 
+// поля политики ревью, незаданные поля не меняются
+type ReviewPolicyPatch struct {
+	ReviewerStrategy  *domain.ReviewerStrategy `json:"reviewer_strategy,omitempty"`
+	MinReviewers      *int                     `json:"min_reviewers,omitempty"`
+	MaxReviewers      *int                     `json:"max_reviewers,omitempty"`
+	AllowUnderstaffed *bool                    `json:"allow_understaffed,omitempty"`
+	FallbackTeams     []string                 `json:"fallback_teams,omitempty"` // [] очищает список
+
+	DefaultMaxOpenReviews      *int `json:"default_max_open_reviews,omitempty"`
+	ClearDefaultMaxOpenReviews bool `json:"clear_default_max_open_reviews,omitempty"` // снимает лимит по умолчанию
+
+	PairDiversityWindow *int `json:"pair_diversity_window,omitempty"` // 0 выключает
+}
+
+// dto for request /team/setReviewPolicy
+type SetReviewPolicyRequest struct {
+	TeamName string `json:"team_name"`
+	ReviewPolicyPatch
+}
+
+// dto for response /team/setReviewPolicy
+type ReviewPolicyResponse struct {
+	TeamName     string              `json:"team_name"`
+	ReviewPolicy domain.ReviewPolicy `json:"review_policy"`
+}
+
+// dto for request /team/setMergePolicy, заменяет политику целиком
+type SetMergePolicyRequest struct {
+	TeamName string `json:"team_name"`
+	domain.MergePolicy
+}
+
+// dto for response /team/setMergePolicy
+type MergePolicyResponse struct {
+	TeamName    string             `json:"team_name"`
+	MergePolicy domain.MergePolicy `json:"merge_policy"`
+}
+
+// dto for request /team/setReviewSLA, заменяет SLA целиком
+type SetReviewSLARequest struct {
+	TeamName string `json:"team_name"`
+	domain.ReviewSLA
+}
+
+// dto for response /team/setReviewSLA
+type ReviewSLAResponse struct {
+	TeamName  string           `json:"team_name"`
+	ReviewSLA domain.ReviewSLA `json:"review_sla"`
+}
+
+// dto for request /team/deactivateMembers
+type DeactivateMembersRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+// dto for response /team/deactivateMembers
+type DeactivateMembersResponse struct {
+	TeamName    string   `json:"team_name"`
+	Deactivated []string `json:"deactivated"`
+	domain.ReassignmentReport
+}
+
 func (r *TeamAddRequest) Validate() error {
 	if r.TeamName == "" {
 		return errors.New("team_name is required")
@@ -31,6 +95,81 @@ func (r *TeamAddRequest) Validate() error {
 		if m.Username == "" {
 			return fmt.Errorf("members[%d].username is required", i)
 		}
+		if m.ReviewWeight != nil && *m.ReviewWeight < 0 {
+			return fmt.Errorf("members[%d].review_weight must not be negative", i)
+		}
+		if m.MaxOpenReviews != nil && *m.MaxOpenReviews < 0 {
+			return fmt.Errorf("members[%d].max_open_reviews must not be negative", i)
+		}
+	}
+	return nil
+}
+
+func (r *SetReviewPolicyRequest) Validate() error {
+	if r.TeamName == "" {
+		return errors.New("team_name is required")
+	}
+	return nil
+}
+
+// применяет заданные поля поверх текущей политики, итог проверяет domain.ReviewPolicy.Validate
+func (p *ReviewPolicyPatch) Apply(policy domain.ReviewPolicy) domain.ReviewPolicy {
+	if p.ReviewerStrategy != nil {
+		policy.ReviewerStrategy = *p.ReviewerStrategy
+	}
+	if p.MinReviewers != nil {
+		policy.MinReviewers = *p.MinReviewers
+	}
+	if p.MaxReviewers != nil {
+		policy.MaxReviewers = *p.MaxReviewers
+	}
+	if p.AllowUnderstaffed != nil {
+		policy.AllowUnderstaffed = *p.AllowUnderstaffed
+	}
+	if p.FallbackTeams != nil {
+		policy.FallbackTeams = p.FallbackTeams
+	}
+	if p.ClearDefaultMaxOpenReviews {
+		policy.DefaultMaxOpenReviews = nil
+	} else if p.DefaultMaxOpenReviews != nil {
+		policy.DefaultMaxOpenReviews = p.DefaultMaxOpenReviews
+	}
+	if p.PairDiversityWindow != nil {
+		policy.PairDiversityWindow = *p.PairDiversityWindow
+	}
+	return policy
+}
+
+func (r *SetMergePolicyRequest) Validate() error {
+	if r.TeamName == "" {
+		return errors.New("team_name is required")
+	}
+	return nil
+}
+
+func (r *SetReviewSLARequest) Validate() error {
+	if r.TeamName == "" {
+		return errors.New("team_name is required")
+	}
+	return nil
+}
+
+func (r *DeactivateMembersRequest) Validate() error {
+	if r.TeamName == "" {
+		return errors.New("team_name is required")
+	}
+	if len(r.UserIDs) == 0 {
+		return errors.New("user_ids must not be empty")
+	}
+	seen := make(map[string]struct{}, len(r.UserIDs))
+	for i, id := range r.UserIDs {
+		if id == "" {
+			return fmt.Errorf("user_ids[%d] must not be empty", i)
+		}
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("user_ids[%d] is duplicated", i)
+		}
+		seen[id] = struct{}{}
 	}
 	return nil
 }
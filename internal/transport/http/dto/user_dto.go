@@ -2,7 +2,14 @@ package dto
 
 import (
 	"errors"
+	"fmt"
 	"pr-reviewer-service/internal/domain"
+	"time"
+)
+
+const (
+	maxTagLength    = 64
+	maxReasonLength = 256
 )
 
 // dto for request /users/setIsActive
@@ -11,11 +18,62 @@ type SetUserActiveRequest struct {
 	IsActive bool   `json:"is_active"`
 }
 
-// dto for response /users/setIsActive
+// dto for response /users/setIsActive: при деактивации — какие OPEN PR переназначены
+type SetUserActiveResponse struct {
+	User domain.User `json:"user"`
+	domain.ReassignmentReport
+}
+
+// dto for request /users/setTags
+type SetUserTagsRequest struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+// dto for request /users/setMaxOpenReviews, null снимает личный лимит
+type SetMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+// dto for response /users/setTags, /users/setMaxOpenReviews,
+// /users/setWorkingHours and /users/get
 type UserResponse struct {
 	User domain.User `json:"user"`
 }
 
+// dto for request /users/setWorkingHours, work_start/work_end null снимают ограничение
+type SetWorkingHoursRequest struct {
+	UserID    string  `json:"user_id"`
+	TimeZone  string  `json:"time_zone"`
+	WorkStart *string `json:"work_start"`
+	WorkEnd   *string `json:"work_end"`
+}
+
+// dto for request /users/addUnavailability
+type AddUnavailabilityRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+// dto for request /users/removeUnavailability
+type RemoveUnavailabilityRequest struct {
+	ID int64 `json:"id"`
+}
+
+// dto for response /users/addUnavailability and /users/removeUnavailability
+type UnavailabilityResponse struct {
+	Unavailability domain.Unavailability `json:"unavailability"`
+}
+
+// dto for response /users/getUnavailability
+type GetUnavailabilityResponse struct {
+	UserID  string                  `json:"user_id"`
+	Periods []domain.Unavailability `json:"periods"`
+}
+
 // dto for response /users/getReview
 type GetUserReviewResponse struct {
 	UserID       string                    `json:"user_id"`
@@ -28,3 +86,72 @@ func (r *SetUserActiveRequest) Validate() error {
 	}
 	return nil
 }
+
+func (r *SetUserTagsRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	for i, t := range r.Tags {
+		if len(t) > maxTagLength {
+			return fmt.Errorf("tags[%d] is longer than %d characters", i, maxTagLength)
+		}
+	}
+	return nil
+}
+
+func (r *SetMaxOpenReviewsRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	if r.MaxOpenReviews != nil && *r.MaxOpenReviews < 0 {
+		return errors.New("max_open_reviews must not be negative")
+	}
+	return nil
+}
+
+func (r *SetWorkingHoursRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	if r.TimeZone == "" {
+		return errors.New("time_zone is required")
+	}
+	if _, err := time.LoadLocation(r.TimeZone); err != nil {
+		return fmt.Errorf("unknown time_zone %q", r.TimeZone)
+	}
+	if (r.WorkStart == nil) != (r.WorkEnd == nil) {
+		return errors.New("work_start and work_end must be set together")
+	}
+	for _, t := range []*string{r.WorkStart, r.WorkEnd} {
+		if t == nil {
+			continue
+		}
+		if _, _, err := domain.ParseClockTime(*t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *AddUnavailabilityRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	if r.StartsAt.IsZero() || r.EndsAt.IsZero() {
+		return errors.New("starts_at and ends_at are required")
+	}
+	if !r.EndsAt.After(r.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+	if len(r.Reason) > maxReasonLength {
+		return fmt.Errorf("reason is longer than %d characters", maxReasonLength)
+	}
+	return nil
+}
+
+func (r *RemoveUnavailabilityRequest) Validate() error {
+	if r.ID <= 0 {
+		return errors.New("id is required")
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddUnavailabilityRequestValidate(t *testing.T) {
+	start := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		req     AddUnavailabilityRequest
+		wantErr string
+	}{
+		{"valid", AddUnavailabilityRequest{UserID: "u1", StartsAt: start, EndsAt: start.Add(time.Hour)}, ""},
+		{"no user", AddUnavailabilityRequest{StartsAt: start, EndsAt: start.Add(time.Hour)}, "user_id is required"},
+		{"no start", AddUnavailabilityRequest{UserID: "u1", EndsAt: start}, "starts_at and ends_at are required"},
+		{"ends before start", AddUnavailabilityRequest{UserID: "u1", StartsAt: start, EndsAt: start.Add(-time.Hour)}, "ends_at must be after starts_at"},
+		{"empty period", AddUnavailabilityRequest{UserID: "u1", StartsAt: start, EndsAt: start}, "ends_at must be after starts_at"},
+		{
+			name:    "reason too long",
+			req:     AddUnavailabilityRequest{UserID: "u1", StartsAt: start, EndsAt: start.Add(time.Hour), Reason: strings.Repeat("a", maxReasonLength+1)},
+			wantErr: "reason is longer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
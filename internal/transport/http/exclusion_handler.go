@@ -0,0 +1,185 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/service"
+	"pr-reviewer-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExclusionHandler struct {
+	svc    *service.ExclusionService
+	logger *slog.Logger
+}
+
+func NewExclusionHandler(svc *service.ExclusionService, logger *slog.Logger) *ExclusionHandler {
+	return &ExclusionHandler{svc: svc, logger: logger}
+}
+
+// POST /exclusions/add
+func (h *ExclusionHandler) Add(c *gin.Context) {
+	var req dto.AddExclusionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	exclusion, violations, err := h.svc.Add(c.Request.Context(), domain.ReviewExclusion{
+		UserID:      req.UserID,
+		OtherUserID: req.OtherUserID,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrExclusionExists):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorExclusionExists,
+					Message: "exclusion for these users already exists",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to add exclusion", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.AddExclusionResponse{
+		Exclusion:  exclusion,
+		Violations: violations,
+	})
+}
+
+// GET /exclusions/list?user_id=..., без user_id — все правила
+func (h *ExclusionHandler) List(c *gin.Context) {
+	exclusions, err := h.svc.List(c.Request.Context(), c.Query("user_id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to list exclusions", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListExclusionsResponse{
+		Exclusions: exclusions,
+	})
+}
+
+// POST /exclusions/remove
+func (h *ExclusionHandler) Remove(c *gin.Context) {
+	var req dto.RemoveExclusionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	exclusion, err := h.svc.Remove(c.Request.Context(), req.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to remove exclusion", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ExclusionResponse{
+		Exclusion: exclusion,
+	})
+}
+
+// GET /exclusions/violations
+func (h *ExclusionHandler) Violations(c *gin.Context) {
+	violations, err := h.svc.GetViolations(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to get exclusion violations", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ExclusionViolationsResponse{
+		Violations: violations,
+	})
+}
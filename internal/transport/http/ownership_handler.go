@@ -0,0 +1,84 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/service"
+	"pr-reviewer-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ограничение на размер загружаемого CODEOWNERS
+const maxCodeownersSize = 1 << 20
+
+type OwnershipHandler struct {
+	svc    *service.OwnershipService
+	logger *slog.Logger
+}
+
+func NewOwnershipHandler(svc *service.OwnershipService, logger *slog.Logger) *OwnershipHandler {
+	return &OwnershipHandler{svc: svc, logger: logger}
+}
+
+// POST /ownership/upload, тело — содержимое CODEOWNERS в text/plain
+func (h *OwnershipHandler) Upload(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxCodeownersSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	rules, err := h.svc.UploadCodeowners(c.Request.Context(), string(body))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCodeowners) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to upload CODEOWNERS", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.OwnershipRulesResponse{
+		Rules: rules,
+	})
+}
+
+// GET /ownership/rules
+func (h *OwnershipHandler) GetRules(c *gin.Context) {
+	rules, err := h.svc.GetRules(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to get ownership rules", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.OwnershipRulesResponse{
+		Rules: rules,
+	})
+}
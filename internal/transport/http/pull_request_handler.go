@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -44,9 +45,29 @@ func (h *PullRequestHandler) Create(c *gin.Context) {
 		return
 	}
 
-	pr, err := h.svc.Create(c.Request.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	h.create(c, service.CreatePRParams{
+		PullRequestID:   req.PullRequestID,
+		PullRequestName: req.PullRequestName,
+		AuthorID:        req.AuthorID,
+		ChangedFiles:    req.ChangedFiles,
+		Labels:          req.Labels,
+		Draft:           req.Draft,
+		Metadata:        req.PullRequestMetadata,
+	})
+}
+
+func (h *PullRequestHandler) create(c *gin.Context, params service.CreatePRParams) {
+	pr, err := h.svc.Create(c.Request.Context(), params)
 	if err != nil {
 		switch {
+		case errors.Is(err, domain.ErrRepositoryNotFound):
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorRepositoryNotFound,
+					Message: "repository not found",
+				},
+			})
+			return
 		case errors.Is(err, domain.ErrPRExists):
 			c.JSON(http.StatusConflict, domain.ErrorResponse{
 				Error: domain.Error{
@@ -55,6 +76,14 @@ func (h *PullRequestHandler) Create(c *gin.Context) {
 				},
 			})
 			return
+		case errors.Is(err, domain.ErrNoCandidate):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNoCandidate,
+					Message: "not enough active reviewers in team",
+				},
+			})
+			return
 		case errors.Is(err, domain.ErrNotFound):
 			// автор или команда не найдены
 			c.JSON(http.StatusNotFound, domain.ErrorResponse{
@@ -105,8 +134,34 @@ func (h *PullRequestHandler) Merge(c *gin.Context) {
 		return
 	}
 
-	pr, err := h.svc.Merge(c.Request.Context(), req.PullRequestID)
+	h.merge(c, req.PullRequestID, req.Force)
+}
+
+func (h *PullRequestHandler) merge(c *gin.Context, prID string, force bool) {
+	pr, err := h.svc.Merge(c.Request.Context(), prID, force)
 	if err != nil {
+		var blocked *domain.MergeBlockedError
+		if errors.As(err, &blocked) {
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorMergeBlocked,
+					Message: "merge conditions are not met",
+					Details: blocked.Conditions,
+				},
+			})
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorInvalidTransition,
+					Message: "only OPEN PR can be merged",
+				},
+			})
+			return
+		}
+
 		if errors.Is(err, domain.ErrNotFound) {
 			c.JSON(http.StatusNotFound, domain.ErrorResponse{
 				Error: domain.Error{
@@ -127,6 +182,240 @@ func (h *PullRequestHandler) Merge(c *gin.Context) {
 		return
 	}
 
+	if force && pr.ForceMerged {
+		h.logger.Warn("pull request force-merged",
+			slog.String("pull_request_id", pr.PullRequestID),
+			slog.Any("bypassed", pr.MergeBypassed))
+	}
+
+	c.JSON(http.StatusOK, dto.PullRequestResponse{
+		PR: pr,
+	})
+}
+
+// POST /pullRequest/markReady
+func (h *PullRequestHandler) MarkReady(c *gin.Context) {
+	var req dto.MarkReadyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	pr, err := h.svc.MarkReady(c.Request.Context(), req.PullRequestID, req.ChangedFiles)
+	if err != nil {
+		h.writeStatusError(c, err, "failed to mark pull request ready")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PullRequestResponse{
+		PR: pr,
+	})
+}
+
+// POST /pullRequest/close
+func (h *PullRequestHandler) Close(c *gin.Context) {
+	h.changeStatus(c, h.svc.Close, "failed to close pull request")
+}
+
+// POST /pullRequest/reopen
+func (h *PullRequestHandler) Reopen(c *gin.Context) {
+	h.changeStatus(c, h.svc.Reopen, "failed to reopen pull request")
+}
+
+func (h *PullRequestHandler) changeStatus(c *gin.Context, change func(ctx context.Context, prID string) (domain.PullRequest, error), logMsg string) {
+	var req dto.ChangeStatusRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	pr, err := change(c.Request.Context(), req.PullRequestID)
+	if err != nil {
+		h.writeStatusError(c, err, logMsg)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PullRequestResponse{
+		PR: pr,
+	})
+}
+
+// ошибки смены статуса PR
+func (h *PullRequestHandler) writeStatusError(c *gin.Context, err error, logMsg string) {
+	switch {
+	case errors.Is(err, domain.ErrPRMerged):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorPRMerged,
+				Message: "PR is already merged",
+			},
+		})
+	case errors.Is(err, domain.ErrInvalidTransition):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorInvalidTransition,
+				Message: "transition is not allowed from current PR status",
+			},
+		})
+	case errors.Is(err, domain.ErrNoCandidate):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNoCandidate,
+				Message: "not enough active reviewers in team",
+			},
+		})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "resource not found",
+			},
+		})
+	default:
+		h.logger.Error(logMsg, slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+	}
+}
+
+// POST /pullRequest/review
+func (h *PullRequestHandler) Review(c *gin.Context) {
+	var req dto.ReviewPullRequestRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	h.saveReview(c, req.PullRequestID, req.ReviewerID, req.State)
+}
+
+// POST /pullRequest/approve, то же что review с APPROVED
+func (h *PullRequestHandler) Approve(c *gin.Context) {
+	var req dto.ApprovePullRequestRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	h.saveReview(c, req.PullRequestID, req.ReviewerID, domain.ReviewStateApproved)
+}
+
+func (h *PullRequestHandler) saveReview(c *gin.Context, prID, reviewerID string, state domain.ReviewState) {
+	pr, err := h.svc.Review(c.Request.Context(), prID, reviewerID, state)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrPRMerged):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorPRMerged,
+					Message: "cannot review merged PR",
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrPRNotOpen):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorPRNotOpen,
+					Message: "cannot review draft or closed PR",
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrNotAssigned):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotAssigned,
+					Message: "reviewer is not assigned to this PR",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to save review", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, dto.PullRequestResponse{
 		PR: pr,
 	})
@@ -156,7 +445,11 @@ func (h *PullRequestHandler) Reassign(c *gin.Context) {
 		return
 	}
 
-	pr, replacedBy, err := h.svc.ReassignReviewer(c.Request.Context(), req.PullRequestID, req.OldUserID)
+	h.reassign(c, req.PullRequestID, req.OldUserID, req.NewUserID)
+}
+
+func (h *PullRequestHandler) reassign(c *gin.Context, prID, oldUserID, newUserID string) {
+	pr, replacedBy, err := h.svc.ReassignReviewer(c.Request.Context(), prID, oldUserID, newUserID)
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrPRMerged):
@@ -168,6 +461,15 @@ func (h *PullRequestHandler) Reassign(c *gin.Context) {
 			})
 			return
 
+		case errors.Is(err, domain.ErrPRNotOpen):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorPRNotOpen,
+					Message: "cannot reassign on draft or closed PR",
+				},
+			})
+			return
+
 		case errors.Is(err, domain.ErrNotAssigned):
 			c.JSON(http.StatusConflict, domain.ErrorResponse{
 				Error: domain.Error{
@@ -184,6 +486,54 @@ func (h *PullRequestHandler) Reassign(c *gin.Context) {
 				},
 			})
 			return
+		case errors.Is(err, domain.ErrReviewerNotFound):
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorReviewerNotFound,
+					Message: "new reviewer not found",
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrReviewerInactive):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorReviewerInactive,
+					Message: "new reviewer is inactive",
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrReviewerIsAuthor):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorReviewerIsAuthor,
+					Message: "author cannot review own PR",
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrAlreadyAssigned):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorAlreadyAssigned,
+					Message: "new reviewer is already assigned to this PR",
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrTeamMismatch):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorTeamMismatch,
+					Message: "new reviewer is not in the team of the replaced reviewer",
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrConflictOfInterest):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorConflictOfInterest,
+					Message: "new reviewer and author must not review each other",
+				},
+			})
+			return
 		case errors.Is(err, domain.ErrNotFound):
 			c.JSON(http.StatusNotFound, domain.ErrorResponse{
 				Error: domain.Error{
@@ -209,3 +559,326 @@ func (h *PullRequestHandler) Reassign(c *gin.Context) {
 		ReplacedBy: replacedBy,
 	})
 }
+
+// GET /pullRequest/assignmentExplain?pull_request_id=...
+func (h *PullRequestHandler) AssignmentExplain(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "pull_request_id is required",
+			},
+		})
+		return
+	}
+
+	explanations, err := h.svc.GetAssignmentExplanations(c.Request.Context(), prID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to get assignment explanation", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AssignmentExplainResponse{
+		PullRequestID: prID,
+		Assignments:   explanations,
+	})
+}
+
+// GET /pullRequest/timeline?pull_request_id=...
+func (h *PullRequestHandler) Timeline(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "pull_request_id is required",
+			},
+		})
+		return
+	}
+
+	events, err := h.svc.GetTimeline(c.Request.Context(), prID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to get pull request timeline", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TimelineResponse{
+		PullRequestID: prID,
+		Events:        events,
+	})
+}
+
+// GET /pullRequest/overdue?team_name=...&all=true
+func (h *PullRequestHandler) Overdue(c *gin.Context) {
+	reviews, err := h.svc.ReviewAges(c.Request.Context(), c.Query("team_name"), c.Query("all") == "true")
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to get overdue reviews", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.OverdueReviewsResponse{Reviews: reviews})
+}
+
+// POST /pullRequest/escalateOverdue
+func (h *PullRequestHandler) EscalateOverdue(c *gin.Context) {
+	escalations, err := h.svc.EscalateOverdue(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to escalate overdue reviews", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	for _, e := range escalations {
+		h.logger.Info("review escalated",
+			slog.String("pull_request_id", e.PullRequestID),
+			slog.String("reviewer_id", e.ReviewerID),
+			slog.String("action", string(e.Action)),
+			slog.String("escalated_to", e.EscalatedTo),
+		)
+	}
+	c.JSON(http.StatusOK, dto.EscalateOverdueResponse{Escalations: escalations})
+}
+
+// POST /pullRequest/addReviewer
+func (h *PullRequestHandler) AddReviewer(c *gin.Context) {
+	var req dto.PullRequestReviewerRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	pr, err := h.svc.AddReviewer(c.Request.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		h.writeReviewersError(c, err, "failed to add reviewer")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PullRequestResponse{
+		PR: pr,
+	})
+}
+
+// POST /pullRequest/removeReviewer
+func (h *PullRequestHandler) RemoveReviewer(c *gin.Context) {
+	var req dto.PullRequestReviewerRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	pr, err := h.svc.RemoveReviewer(c.Request.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		h.writeReviewersError(c, err, "failed to remove reviewer")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PullRequestResponse{
+		PR: pr,
+	})
+}
+
+// PUT /pullRequest/reviewers
+func (h *PullRequestHandler) SetReviewers(c *gin.Context) {
+	var req dto.SetReviewersRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	pr, err := h.svc.SetReviewers(c.Request.Context(), req.PullRequestID, req.Reviewers)
+	if err != nil {
+		h.writeReviewersError(c, err, "failed to set reviewers")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PullRequestResponse{
+		PR: pr,
+	})
+}
+
+// ошибки ручного изменения ревьюверов
+func (h *PullRequestHandler) writeReviewersError(c *gin.Context, err error, logMsg string) {
+	switch {
+	case errors.Is(err, domain.ErrPRMerged):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorPRMerged,
+				Message: "cannot change reviewers on merged PR",
+			},
+		})
+	case errors.Is(err, domain.ErrPRNotOpen):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorPRNotOpen,
+				Message: "cannot change reviewers on draft or closed PR",
+			},
+		})
+	case errors.Is(err, domain.ErrNotAssigned):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotAssigned,
+				Message: "reviewer is not assigned to this PR",
+			},
+		})
+	case errors.Is(err, domain.ErrAlreadyAssigned):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorAlreadyAssigned,
+				Message: "reviewer is already assigned to this PR",
+			},
+		})
+	case errors.Is(err, domain.ErrReviewerIsAuthor):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorReviewerIsAuthor,
+				Message: "author cannot review own PR",
+			},
+		})
+	case errors.Is(err, domain.ErrReviewerInactive):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorReviewerInactive,
+				Message: "reviewer is inactive",
+			},
+		})
+	case errors.Is(err, domain.ErrTooManyReviewers):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorTooManyReviewers,
+				Message: "too many reviewers for author's team",
+			},
+		})
+	case errors.Is(err, domain.ErrConflictOfInterest):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorConflictOfInterest,
+				Message: "reviewer and author must not review each other",
+			},
+		})
+	case errors.Is(err, domain.ErrReviewerNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorReviewerNotFound,
+				Message: "reviewer not found",
+			},
+		})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "resource not found",
+			},
+		})
+	default:
+		h.logger.Error(logMsg, slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+	}
+}
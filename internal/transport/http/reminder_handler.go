@@ -0,0 +1,78 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/service"
+	"pr-reviewer-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderHandler struct {
+	svc    *service.ReminderService
+	logger *slog.Logger
+}
+
+func NewReminderHandler(svc *service.ReminderService, logger *slog.Logger) *ReminderHandler {
+	return &ReminderHandler{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// GET /pullRequest/reminders?pull_request_id=...&user_id=..., фильтры необязательны
+func (h *ReminderHandler) List(c *gin.Context) {
+	reminders, err := h.svc.GetReminders(c.Request.Context(), c.Query("pull_request_id"), c.Query("user_id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to get reminders", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RemindersResponse{Reminders: reminders})
+}
+
+// POST /pullRequest/sendReminders, тот же проход, что у периодической задачи, и под ее локом
+func (h *ReminderHandler) Send(c *gin.Context) {
+	report, err := h.svc.TriggerReminders(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, domain.ErrJobRunning) {
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorJobRunning,
+					Message: "reminders are being sent",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to send reminders", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
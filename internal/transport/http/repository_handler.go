@@ -0,0 +1,152 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/service"
+	"pr-reviewer-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RepositoryHandler struct {
+	svc    *service.RepositoryService
+	logger *slog.Logger
+}
+
+func NewRepositoryHandler(svc *service.RepositoryService, logger *slog.Logger) *RepositoryHandler {
+	return &RepositoryHandler{svc: svc, logger: logger}
+}
+
+// POST /repository/add
+func (h *RepositoryHandler) Add(c *gin.Context) {
+	var req dto.AddRepositoryRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	repository, err := h.svc.Add(c.Request.Context(), domain.Repository{
+		Name:         req.Name,
+		TeamName:     req.TeamName,
+		ReviewPolicy: req.RepositoryReviewPolicy,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidPolicy):
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: err.Error(),
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrRepositoryExists):
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorRepositoryExists,
+					Message: "repository already exists",
+				},
+			})
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "team not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to add repository", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.RepositoryResponse{
+		Repository: repository,
+	})
+}
+
+// GET /repository/get?name=...
+func (h *RepositoryHandler) Get(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "name is required",
+			},
+		})
+		return
+	}
+
+	repository, err := h.svc.Get(c.Request.Context(), name)
+	if err != nil {
+		if errors.Is(err, domain.ErrRepositoryNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorRepositoryNotFound,
+					Message: "repository not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to get repository", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RepositoryResponse{
+		Repository: repository,
+	})
+}
+
+// GET /repository/list
+func (h *RepositoryHandler) List(c *gin.Context) {
+	repositories, err := h.svc.List(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to list repositories", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListRepositoriesResponse{
+		Repositories: repositories,
+	})
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/service"
+	"pr-reviewer-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PR в пространстве репозитория: адресуются парой (repository, number),
+// дальше обрабатываются так же, как /pullRequest/*
+
+// POST /repository/pullRequest/create
+func (h *PullRequestHandler) CreateInRepository(c *gin.Context) {
+	var req dto.CreateRepositoryPullRequestRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	metadata := req.PullRequestMetadata
+	metadata.Repository = req.Repository
+	h.create(c, service.CreatePRParams{
+		PullRequestID:   domain.PullRequestKey(req.Repository, req.Number),
+		Number:          req.Number,
+		PullRequestName: req.PullRequestName,
+		AuthorID:        req.AuthorID,
+		ChangedFiles:    req.ChangedFiles,
+		Labels:          req.Labels,
+		Draft:           req.Draft,
+		Metadata:        metadata,
+	})
+}
+
+// POST /repository/pullRequest/merge
+func (h *PullRequestHandler) MergeInRepository(c *gin.Context) {
+	var req dto.MergeRepositoryPullRequestRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolveNumber(c, req.Repository, req.Number)
+	if !ok {
+		return
+	}
+	h.merge(c, prID, req.Force)
+}
+
+// POST /repository/pullRequest/reassign
+func (h *PullRequestHandler) ReassignInRepository(c *gin.Context) {
+	var req dto.ReassignRepositoryReviewerRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolveNumber(c, req.Repository, req.Number)
+	if !ok {
+		return
+	}
+	h.reassign(c, prID, req.OldUserID, req.NewUserID)
+}
+
+// находит pull_request_id, при ошибке сам пишет ответ
+func (h *PullRequestHandler) resolveNumber(c *gin.Context, repository, number string) (string, bool) {
+	prID, err := h.svc.ResolveNumber(c.Request.Context(), repository, number)
+	if err == nil {
+		return prID, true
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrRepositoryNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorRepositoryNotFound,
+				Message: "repository not found",
+			},
+		})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "resource not found",
+			},
+		})
+	default:
+		h.logger.Error("failed to resolve pull request number", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+	}
+	return "", false
+}
@@ -2,17 +2,23 @@ package http
 
 import (
 	"log/slog"
+	"pr-reviewer-service/internal/scheduler"
 	"pr-reviewer-service/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Dependencies struct {
-	TeamService  *service.TeamService
-	UserService  *service.UserService
-	PRService    *service.PRService
-	StatsService *service.StatsService
-	Logger       *slog.Logger
+	TeamService       *service.TeamService
+	UserService       *service.UserService
+	PRService         *service.PRService
+	StatsService      *service.StatsService
+	OwnershipService  *service.OwnershipService
+	ExclusionService  *service.ExclusionService
+	RepositoryService *service.RepositoryService
+	ReminderService   *service.ReminderService
+	Scheduler         *scheduler.Scheduler
+	Logger            *slog.Logger
 }
 
 func NewRouter(deps Dependencies) *gin.Engine {
@@ -25,6 +31,11 @@ func NewRouter(deps Dependencies) *gin.Engine {
 	userHandler := NewUserHandler(deps.UserService, deps.Logger)
 	prHandler := NewPullRequestHandler(deps.PRService, deps.Logger)
 	statsHandler := NewStatsHandler(deps.StatsService, deps.Logger)
+	ownershipHandler := NewOwnershipHandler(deps.OwnershipService, deps.Logger)
+	exclusionHandler := NewExclusionHandler(deps.ExclusionService, deps.Logger)
+	repositoryHandler := NewRepositoryHandler(deps.RepositoryService, deps.Logger)
+	reminderHandler := NewReminderHandler(deps.ReminderService, deps.Logger)
+	adminHandler := NewAdminHandler(deps.Scheduler, deps.Logger)
 
 	r.GET("/health", func(c *gin.Context) {
 		c.Status(200)
@@ -33,17 +44,64 @@ func NewRouter(deps Dependencies) *gin.Engine {
 	// Teams
 	r.POST("/team/add", teamHandler.AddTeam)
 	r.GET("/team/get", teamHandler.GetTeam)
+	r.POST("/team/setReviewPolicy", teamHandler.SetReviewPolicy)
+	r.POST("/team/setMergePolicy", teamHandler.SetMergePolicy)
+	r.POST("/team/setReviewSLA", teamHandler.SetReviewSLA)
+	r.POST("/team/deactivateMembers", teamHandler.DeactivateMembers)
 
 	// Users
 	r.POST("/users/setIsActive", userHandler.SetIsActive)
 	r.GET("/users/getReview", userHandler.GetReview)
+	r.GET("/users/get", userHandler.GetUser)
+	r.POST("/users/setTags", userHandler.SetTags)
+	r.POST("/users/setMaxOpenReviews", userHandler.SetMaxOpenReviews)
+	r.POST("/users/setWorkingHours", userHandler.SetWorkingHours)
+	r.POST("/users/addUnavailability", userHandler.AddUnavailability)
+	r.GET("/users/getUnavailability", userHandler.GetUnavailability)
+	r.POST("/users/removeUnavailability", userHandler.RemoveUnavailability)
 
 	// PullRequests
 	r.POST("/pullRequest/create", prHandler.Create)
 	r.POST("/pullRequest/merge", prHandler.Merge)
 	r.POST("/pullRequest/reassign", prHandler.Reassign)
+	r.POST("/pullRequest/markReady", prHandler.MarkReady)
+	r.POST("/pullRequest/close", prHandler.Close)
+	r.POST("/pullRequest/reopen", prHandler.Reopen)
+	r.POST("/pullRequest/review", prHandler.Review)
+	r.POST("/pullRequest/approve", prHandler.Approve)
+	r.POST("/pullRequest/addReviewer", prHandler.AddReviewer)
+	r.POST("/pullRequest/removeReviewer", prHandler.RemoveReviewer)
+	r.PUT("/pullRequest/reviewers", prHandler.SetReviewers)
+	r.GET("/pullRequest/assignmentExplain", prHandler.AssignmentExplain)
+	r.GET("/pullRequest/timeline", prHandler.Timeline)
+	r.GET("/pullRequest/overdue", prHandler.Overdue)
+	r.POST("/pullRequest/escalateOverdue", prHandler.EscalateOverdue)
+	r.GET("/pullRequest/reminders", reminderHandler.List)
+	r.POST("/pullRequest/sendReminders", reminderHandler.Send)
+
+	// Repositories
+	r.POST("/repository/add", repositoryHandler.Add)
+	r.GET("/repository/get", repositoryHandler.Get)
+	r.GET("/repository/list", repositoryHandler.List)
+	r.POST("/repository/pullRequest/create", prHandler.CreateInRepository)
+	r.POST("/repository/pullRequest/merge", prHandler.MergeInRepository)
+	r.POST("/repository/pullRequest/reassign", prHandler.ReassignInRepository)
+
+	// Code ownership
+	r.POST("/ownership/upload", ownershipHandler.Upload)
+	r.GET("/ownership/rules", ownershipHandler.GetRules)
+
+	// Conflict-of-interest exclusions
+	r.POST("/exclusions/add", exclusionHandler.Add)
+	r.GET("/exclusions/list", exclusionHandler.List)
+	r.POST("/exclusions/remove", exclusionHandler.Remove)
+	r.GET("/exclusions/violations", exclusionHandler.Violations)
 
 	r.GET("/stats", statsHandler.GetStats)
+
+	// Scheduler
+	r.GET("/admin/jobs", adminHandler.Jobs)
+
 	// swagger
 	registerSwagger(r)
 
@@ -1,8 +1,10 @@
 package http
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"pr-reviewer-service/internal/domain"
 	"pr-reviewer-service/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -20,11 +22,22 @@ func NewStatsHandler(statsService *service.StatsService, logger *slog.Logger) *S
 	}
 }
 
+// GET /stats?repository=..., без repository — по всем репозиториям
 func (h *StatsHandler) GetStats(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	stats, err := h.statsService.GetStats(ctx)
+	stats, err := h.statsService.GetStats(ctx, c.Query("repository"))
 	if err != nil {
+		if errors.Is(err, domain.ErrRepositoryNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorRepositoryNotFound,
+					Message: "repository not found",
+				},
+			})
+			return
+		}
+
 		h.logger.Error("failed to get stats", slog.Any("error", err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
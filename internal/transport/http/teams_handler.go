@@ -48,12 +48,26 @@ func (h *TeamHandler) AddTeam(c *gin.Context) {
 	}
 
 	team := domain.Team{
-		TeamName: req.TeamName,
-		Members:  req.Members,
+		TeamName:     req.TeamName,
+		Members:      req.Members,
+		ReviewPolicy: domain.DefaultReviewPolicy(),
+	}
+	if req.ReviewPolicy != nil {
+		team.ReviewPolicy = req.ReviewPolicy.Apply(team.ReviewPolicy)
 	}
 
 	created, err := h.svc.CreateTeam(c.Request.Context(), team)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPolicy) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
 		if errors.Is(err, domain.ErrTeamExists) {
 			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
 				Error: domain.Error{
@@ -116,3 +130,238 @@ func (h *TeamHandler) GetTeam(c *gin.Context) {
 
 	c.JSON(http.StatusOK, team)
 }
+
+// POST /team/setReviewPolicy
+func (h *TeamHandler) SetReviewPolicy(c *gin.Context) {
+	var req dto.SetReviewPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	policy, err := h.svc.UpdateReviewPolicy(c.Request.Context(), req.TeamName, req.Apply)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPolicy) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to update review policy", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReviewPolicyResponse{
+		TeamName:     req.TeamName,
+		ReviewPolicy: policy,
+	})
+}
+
+// POST /team/setMergePolicy
+func (h *TeamHandler) SetMergePolicy(c *gin.Context) {
+	var req dto.SetMergePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	policy, err := h.svc.SetMergePolicy(c.Request.Context(), req.TeamName, req.MergePolicy)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPolicy) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to update merge policy", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MergePolicyResponse{
+		TeamName:    req.TeamName,
+		MergePolicy: policy,
+	})
+}
+
+// POST /team/setReviewSLA
+func (h *TeamHandler) SetReviewSLA(c *gin.Context) {
+	var req dto.SetReviewSLARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	sla, err := h.svc.SetReviewSLA(c.Request.Context(), req.TeamName, req.ReviewSLA)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPolicy) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to update review sla", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReviewSLAResponse{
+		TeamName:  req.TeamName,
+		ReviewSLA: sla,
+	})
+}
+
+// POST /team/deactivateMembers
+func (h *TeamHandler) DeactivateMembers(c *gin.Context) {
+	var req dto.DeactivateMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	report, err := h.svc.DeactivateMembers(c.Request.Context(), req.TeamName, req.UserIDs)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "team or some of the users not found in team",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to deactivate team members", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.DeactivateMembersResponse{
+		TeamName:           req.TeamName,
+		Deactivated:        req.UserIDs,
+		ReassignmentReport: report,
+	})
+}
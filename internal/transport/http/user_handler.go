@@ -44,7 +44,7 @@ func (h *UserHandler) SetIsActive(c *gin.Context) {
 		return
 	}
 
-	user, err := h.svc.SetActive(c.Request.Context(), req.UserID, req.IsActive)
+	user, report, err := h.svc.SetActive(c.Request.Context(), req.UserID, req.IsActive)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			c.JSON(http.StatusNotFound, domain.ErrorResponse{
@@ -66,12 +66,13 @@ func (h *UserHandler) SetIsActive(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.UserResponse{
-		User: user,
+	c.JSON(http.StatusOK, dto.SetUserActiveResponse{
+		User:               user,
+		ReassignmentReport: report,
 	})
 }
 
-// GET /users/getReview?user_id=...
+// GET /users/getReview?user_id=...&repository=..., без repository — все репозитории
 func (h *UserHandler) GetReview(c *gin.Context) {
 	userID := c.Query("user_id")
 	if userID == "" {
@@ -84,8 +85,18 @@ func (h *UserHandler) GetReview(c *gin.Context) {
 		return
 	}
 
-	prs, err := h.svc.GetReviewPullRequests(c.Request.Context(), userID)
+	prs, err := h.svc.GetReviewPullRequests(c.Request.Context(), userID, c.Query("repository"))
 	if err != nil {
+		if errors.Is(err, domain.ErrRepositoryNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorRepositoryNotFound,
+					Message: "repository not found",
+				},
+			})
+			return
+		}
+
 		if errors.Is(err, domain.ErrNotFound) {
 			c.JSON(http.StatusNotFound, domain.ErrorResponse{
 				Error: domain.Error{
@@ -113,3 +124,344 @@ func (h *UserHandler) GetReview(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// POST /users/setTags
+func (h *UserHandler) SetTags(c *gin.Context) {
+	var req dto.SetUserTagsRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	user, err := h.svc.SetTags(c.Request.Context(), req.UserID, req.Tags)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to set user tags", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserResponse{
+		User: user,
+	})
+}
+
+// POST /users/setMaxOpenReviews
+func (h *UserHandler) SetMaxOpenReviews(c *gin.Context) {
+	var req dto.SetMaxOpenReviewsRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	user, err := h.svc.SetMaxOpenReviews(c.Request.Context(), req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to set user max open reviews", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserResponse{
+		User: user,
+	})
+}
+
+// POST /users/setWorkingHours
+func (h *UserHandler) SetWorkingHours(c *gin.Context) {
+	var req dto.SetWorkingHoursRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	user, err := h.svc.SetWorkingHours(c.Request.Context(), req.UserID, req.TimeZone, req.WorkStart, req.WorkEnd)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to set working hours", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserResponse{
+		User: user,
+	})
+}
+
+// GET /users/get?user_id=...
+func (h *UserHandler) GetUser(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "user_id is required",
+			},
+		})
+		return
+	}
+
+	user, err := h.svc.GetUser(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to get user", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserResponse{
+		User: user,
+	})
+}
+
+// POST /users/addUnavailability
+func (h *UserHandler) AddUnavailability(c *gin.Context) {
+	var req dto.AddUnavailabilityRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	period, err := h.svc.AddUnavailability(c.Request.Context(), domain.Unavailability{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to add unavailability", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.UnavailabilityResponse{
+		Unavailability: period,
+	})
+}
+
+// POST /users/removeUnavailability
+func (h *UserHandler) RemoveUnavailability(c *gin.Context) {
+	var req dto.RemoveUnavailabilityRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	period, err := h.svc.RemoveUnavailability(c.Request.Context(), req.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to remove unavailability", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UnavailabilityResponse{
+		Unavailability: period,
+	})
+}
+
+// GET /users/getUnavailability?user_id=...
+func (h *UserHandler) GetUnavailability(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "user_id is required",
+			},
+		})
+		return
+	}
+
+	periods, err := h.svc.GetUnavailability(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Error: domain.Error{
+					Code:    domain.ErrorNotFound,
+					Message: "resource not found",
+				},
+			})
+			return
+		}
+
+		h.logger.Error("failed to get unavailability", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Error: domain.Error{
+				Code:    domain.ErrorNotFound,
+				Message: "internal error",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetUnavailabilityResponse{
+		UserID:  userID,
+		Periods: periods,
+	})
+}
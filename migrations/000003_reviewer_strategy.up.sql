@@ -0,0 +1,6 @@
+-- стратегия выбора ревьюверов для команды и курсор для round-robin
+ALTER TABLE teams ADD COLUMN reviewer_strategy TEXT NOT NULL DEFAULT 'LEAST_LOADED';
+ALTER TABLE teams ADD COLUMN round_robin_cursor TEXT;
+
+-- вес пользователя для стратегии WEIGHTED
+ALTER TABLE users ADD COLUMN review_weight INTEGER NOT NULL DEFAULT 1 CHECK (review_weight >= 0);
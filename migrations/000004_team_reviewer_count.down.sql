@@ -0,0 +1,6 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS understaffed;
+
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_reviewers_range_check;
+ALTER TABLE teams DROP COLUMN IF EXISTS allow_understaffed;
+ALTER TABLE teams DROP COLUMN IF EXISTS max_reviewers;
+ALTER TABLE teams DROP COLUMN IF EXISTS min_reviewers;
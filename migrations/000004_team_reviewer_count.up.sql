@@ -0,0 +1,9 @@
+-- сколько ревьюверов назначать на PR команды
+ALTER TABLE teams ADD COLUMN min_reviewers INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE teams ADD COLUMN max_reviewers INTEGER NOT NULL DEFAULT 2;
+-- если минимум не набран: true - создаем PR с флагом understaffed, false - NO_CANDIDATE
+ALTER TABLE teams ADD COLUMN allow_understaffed BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE teams ADD CONSTRAINT teams_reviewers_range_check
+    CHECK (min_reviewers >= 0 AND max_reviewers >= min_reviewers);
+
+ALTER TABLE pull_requests ADD COLUMN understaffed BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,11 @@
+-- упорядоченный список команд, из которых добираются ревьюверы, если своей команды не хватает
+CREATE TABLE team_fallbacks (
+    team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    fallback_team TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (team_name, fallback_team),
+    CHECK (team_name <> fallback_team)
+);
+
+-- ревьювер взят не из команды автора, а из запасного пула
+ALTER TABLE pr_reviewers ADD COLUMN is_fallback BOOLEAN NOT NULL DEFAULT FALSE;
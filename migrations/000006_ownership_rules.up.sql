@@ -0,0 +1,7 @@
+-- правила владения кодом из загруженного CODEOWNERS, при совпадении нескольких побеждает последнее
+CREATE TABLE ownership_rules (
+    position INTEGER PRIMARY KEY,
+    pattern TEXT NOT NULL,
+    owner_teams TEXT[] NOT NULL DEFAULT '{}',
+    owner_users TEXT[] NOT NULL DEFAULT '{}'
+);
@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS labels;
+
+DROP TABLE IF EXISTS user_tags;
@@ -0,0 +1,9 @@
+-- экспертиза пользователя (postgres, ios, security, ...)
+CREATE TABLE user_tags (
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (user_id, tag)
+);
+
+-- метки PR, сопоставляются с тегами ревьюверов
+ALTER TABLE pull_requests ADD COLUMN labels TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+-- сколько OPEN PR одновременно может ревьюить пользователь, NULL - берется значение команды
+ALTER TABLE users ADD COLUMN max_open_reviews INTEGER CHECK (max_open_reviews >= 0);
+-- значение по умолчанию для участников команды, NULL - без ограничения
+ALTER TABLE teams ADD COLUMN default_max_open_reviews INTEGER CHECK (default_max_open_reviews >= 0);
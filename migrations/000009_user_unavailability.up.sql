@@ -0,0 +1,12 @@
+-- периоды отсутствия (отпуск, больничный): пока период покрывает текущий момент,
+-- пользователь не выбирается ревьювером
+CREATE TABLE user_unavailability (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(user_id, ends_at);
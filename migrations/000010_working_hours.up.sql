@@ -0,0 +1,7 @@
+-- рабочее время пользователя в его часовом поясе, "HH:MM"; NULL - доступен в любое время.
+-- work_start > work_end означает окно через полночь
+ALTER TABLE users
+    ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC',
+    ADD COLUMN work_start TEXT CHECK (work_start ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+    ADD COLUMN work_end TEXT CHECK (work_end ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+    ADD CHECK ((work_start IS NULL) = (work_end IS NULL));
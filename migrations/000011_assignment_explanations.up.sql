@@ -0,0 +1,11 @@
+-- кандидаты, рассмотренные при каждом назначении ревьюверов, и причины выбора/пропуска
+CREATE TABLE assignment_explanations (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    replaced_reviewer_id TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    candidates JSONB NOT NULL
+);
+
+CREATE INDEX idx_assignment_explanations_pr ON assignment_explanations(pull_request_id, id);
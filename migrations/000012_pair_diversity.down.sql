@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pull_requests_author_created;
+ALTER TABLE teams DROP COLUMN IF EXISTS pair_diversity_window;
@@ -0,0 +1,4 @@
+-- сколько последних PR автора учитывать, чтобы не назначать одних и тех же ревьюверов, 0 - выключено
+ALTER TABLE teams ADD COLUMN pair_diversity_window INTEGER NOT NULL DEFAULT 0 CHECK (pair_diversity_window >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_created ON pull_requests (author_id, created_at DESC);
@@ -0,0 +1,13 @@
+-- пары пользователей, которые не ревьюят PR друг друга (конфликт интересов).
+-- Пара хранится один раз: user_id < other_user_id
+CREATE TABLE review_exclusions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    other_user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (user_id < other_user_id),
+    UNIQUE (user_id, other_user_id)
+);
+
+CREATE INDEX idx_review_exclusions_other_user ON review_exclusions(other_user_id);
@@ -0,0 +1,4 @@
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS assigned_at,
+    DROP COLUMN IF EXISTS state;
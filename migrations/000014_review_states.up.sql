@@ -0,0 +1,12 @@
+-- решение ревьювера: PENDING, пока он не ответил
+ALTER TABLE pr_reviewers
+    ADD COLUMN state TEXT NOT NULL DEFAULT 'PENDING'
+        CHECK (state IN ('PENDING', 'APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    ADD COLUMN assigned_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN reviewed_at TIMESTAMPTZ;
+
+-- для уже назначенных точное время неизвестно, берем создание PR
+UPDATE pr_reviewers r
+SET assigned_at = pr.created_at
+FROM pull_requests pr
+WHERE pr.pull_request_id = r.pull_request_id AND pr.created_at IS NOT NULL;
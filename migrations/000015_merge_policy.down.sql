@@ -0,0 +1,8 @@
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS merge_bypassed,
+    DROP COLUMN IF EXISTS force_merged;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS require_lead_approval,
+    DROP COLUMN IF EXISTS team_lead_id,
+    DROP COLUMN IF EXISTS required_approvals;
@@ -0,0 +1,10 @@
+-- условия merge для PR авторов команды
+ALTER TABLE teams
+    ADD COLUMN required_approvals INTEGER NOT NULL DEFAULT 0 CHECK (required_approvals >= 0),
+    ADD COLUMN team_lead_id TEXT REFERENCES users(user_id) ON DELETE SET NULL,
+    ADD COLUMN require_lead_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- merge с force: какие условия были не выполнены
+ALTER TABLE pull_requests
+    ADD COLUMN force_merged BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN merge_bypassed TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,11 @@
+-- до 000016 были только OPEN и MERGED: черновик становится открытым PR,
+-- закрытый без merge — завершенным, чтобы на него больше не назначались ревьюверы
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'DRAFT';
+UPDATE pull_requests SET status = 'MERGED', merged_at = COALESCE(merged_at, closed_at) WHERE status = 'CLOSED';
+
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS ready_at;
@@ -0,0 +1,11 @@
+-- черновики и закрытые без merge PR
+ALTER TABLE pull_requests
+    ADD COLUMN ready_at TIMESTAMPTZ,
+    ADD COLUMN closed_at TIMESTAMPTZ;
+
+-- все существующие PR создавались сразу готовыми к ревью
+UPDATE pull_requests SET ready_at = created_at;
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check
+        CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED'));
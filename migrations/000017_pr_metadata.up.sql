@@ -0,0 +1,10 @@
+-- данные PR из системы контроля версий
+ALTER TABLE pull_requests
+    ADD COLUMN repository TEXT NOT NULL DEFAULT '',
+    ADD COLUMN source_branch TEXT NOT NULL DEFAULT '',
+    ADD COLUMN target_branch TEXT NOT NULL DEFAULT '',
+    ADD COLUMN url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN lines_added INTEGER NOT NULL DEFAULT 0 CHECK (lines_added >= 0),
+    ADD COLUMN lines_removed INTEGER NOT NULL DEFAULT 0 CHECK (lines_removed >= 0),
+    ADD COLUMN files_changed INTEGER NOT NULL DEFAULT 0 CHECK (files_changed >= 0);
@@ -0,0 +1,7 @@
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_repository_number_key,
+    DROP CONSTRAINT IF EXISTS pull_requests_repository_fkey,
+    ALTER COLUMN repository SET DEFAULT '',
+    DROP COLUMN IF EXISTS number;
+
+DROP TABLE IF EXISTS repositories;
@@ -0,0 +1,30 @@
+CREATE TABLE repositories (
+    name TEXT PRIMARY KEY,
+    team_name TEXT REFERENCES teams(team_name) ON DELETE SET NULL,
+    min_reviewers INTEGER CHECK (min_reviewers >= 0),
+    max_reviewers INTEGER CHECK (max_reviewers >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (min_reviewers IS NULL OR max_reviewers IS NULL OR min_reviewers <= max_reviewers)
+);
+
+-- существующие PR: без репозитория — в default, остальные репозитории создаются по имени
+INSERT INTO repositories (name) VALUES ('default');
+
+UPDATE pull_requests SET repository = 'default' WHERE repository = '';
+
+INSERT INTO repositories (name)
+SELECT DISTINCT repository FROM pull_requests
+ON CONFLICT (name) DO NOTHING;
+
+-- номер PR внутри репозитория, у старых PR совпадает с pull_request_id
+ALTER TABLE pull_requests ADD COLUMN number TEXT;
+
+UPDATE pull_requests SET number = pull_request_id;
+
+ALTER TABLE pull_requests
+    ALTER COLUMN number SET NOT NULL,
+    ALTER COLUMN repository SET DEFAULT 'default',
+    ADD CONSTRAINT pull_requests_repository_fkey
+        FOREIGN KEY (repository) REFERENCES repositories(name),
+    ADD CONSTRAINT pull_requests_repository_number_key
+        UNIQUE (repository, number);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS review_escalations;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS sla_escalation_user_id,
+    DROP COLUMN IF EXISTS sla_escalation_action,
+    DROP COLUMN IF EXISTS sla_escalation_hours,
+    DROP COLUMN IF EXISTS sla_response_hours;
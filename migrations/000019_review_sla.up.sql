@@ -0,0 +1,17 @@
+ALTER TABLE teams
+    ADD COLUMN sla_response_hours INTEGER NOT NULL DEFAULT 0 CHECK (sla_response_hours >= 0),
+    ADD COLUMN sla_escalation_hours INTEGER NOT NULL DEFAULT 0 CHECK (sla_escalation_hours >= 0),
+    ADD COLUMN sla_escalation_action TEXT CHECK (sla_escalation_action IN ('ADD_USER', 'REASSIGN')),
+    ADD COLUMN sla_escalation_user_id TEXT REFERENCES users(user_id) ON DELETE SET NULL;
+
+-- одно назначение эскалируется один раз
+CREATE TABLE review_escalations (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    reviewer_id TEXT NOT NULL,
+    assigned_at TIMESTAMPTZ NOT NULL,
+    action TEXT NOT NULL,
+    escalated_to TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (pull_request_id, reviewer_id, assigned_at)
+);
@@ -0,0 +1,11 @@
+-- история напоминаний ревьюверам, по ней же не чаще раза в интервал
+CREATE TABLE review_reminders (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    reviewer_id TEXT NOT NULL,
+    channel TEXT NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_review_reminders_pr_reviewer ON review_reminders (pull_request_id, reviewer_id, sent_at);
+CREATE INDEX idx_review_reminders_reviewer ON review_reminders (reviewer_id, sent_at);
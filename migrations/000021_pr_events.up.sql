@@ -0,0 +1,39 @@
+-- история PR: только добавление, порядок — (created_at, id)
+CREATE TABLE pr_events (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    reviewer_id TEXT,
+    old_reviewer_id TEXT,
+    new_reviewer_id TEXT,
+    status TEXT,
+    state TEXT,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_pr_events_pr ON pr_events (pull_request_id, created_at, id);
+
+-- для существующих PR восстанавливаем то, что известно из текущих данных
+INSERT INTO pr_events (pull_request_id, type, reviewer_id, status, state, reason, created_at)
+SELECT pull_request_id, type, reviewer_id, status, state, 'MIGRATION', created_at
+FROM (SELECT pr.pull_request_id, 'CREATED' AS type, NULL AS reviewer_id,
+             CASE WHEN pr.ready_at IS NULL THEN 'DRAFT' ELSE 'OPEN' END AS status, NULL AS state,
+             COALESCE(pr.created_at, now()) AS created_at, 0 AS ord
+      FROM pull_requests pr
+      UNION ALL
+      SELECT r.pull_request_id, 'REVIEWER_ASSIGNED', r.reviewer_id, NULL, NULL, r.assigned_at, 1
+      FROM pr_reviewers r
+      UNION ALL
+      SELECT r.pull_request_id, 'REVIEWED', r.reviewer_id, NULL, r.state, r.reviewed_at, 2
+      FROM pr_reviewers r
+      WHERE r.reviewed_at IS NOT NULL AND r.state <> 'PENDING'
+      UNION ALL
+      SELECT pr.pull_request_id, 'MERGED', NULL, 'MERGED', NULL, COALESCE(pr.merged_at, now()), 3
+      FROM pull_requests pr
+      WHERE pr.status = 'MERGED'
+      UNION ALL
+      SELECT pr.pull_request_id, 'CLOSED', NULL, 'CLOSED', NULL, COALESCE(pr.closed_at, now()), 3
+      FROM pull_requests pr
+      WHERE pr.status = 'CLOSED') e
+ORDER BY created_at, ord, pull_request_id, reviewer_id;
@@ -0,0 +1,7 @@
+-- последний запуск каждой задачи планировщика по расписанию: реплика, опоздавшая
+-- из-за jitter, не повторяет уже выполненный запуск
+CREATE TABLE job_runs (
+    job_name TEXT PRIMARY KEY,
+    slot TIMESTAMPTZ NOT NULL,
+    claimed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1 @@
+ALTER TABLE review_escalations DROP COLUMN IF EXISTS error;
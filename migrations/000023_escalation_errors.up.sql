@@ -0,0 +1,3 @@
+-- ADD_USER, который не удалось применить: пользователь неактивен, отсутствует,
+-- в паре исключений с автором или у PR уже max_reviewers ревьюверов
+ALTER TABLE review_escalations ADD COLUMN error TEXT;